@@ -0,0 +1,167 @@
+package stderr
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Join returns an aggregate typed error holding the supplied errors as independent branches, for instance one per
+// failed item of a batch. Each branch may itself be a chain. Nil errors are skipped, and nil is returned if all errors
+// are nil. When placed in a chain of errors, errors.Is and errors.As consult every branch, and View.Context holds the
+// nodes of each branch as children of an aggregate typed node.
+//
+// Errors that wrap multiple errors, like the ones returned by errors.Join, are converted to aggregate typed errors
+// when placed in a chain. Unless the message of such an error merely joins the messages of its branches with newlines,
+// as errors.Join does, it is kept as the message of the aggregate typed error, for instance the one returned by
+// fmt.Errorf("load %s: %w, %w", ...).
+func Join(errs ...error) error {
+	var branches []error
+	for _, err := range errs {
+		if err != nil {
+			branches = append(branches, err)
+		}
+	}
+
+	if len(branches) == 0 {
+		return nil
+	}
+
+	return &AggregateError{errs: branches}
+}
+
+// aggregate converts the error wrapping the branches into an aggregate typed error, keeping its message unless it
+// merely joins the messages of the branches like errors.Join. The error itself is kept as well, so that errors.Is and
+// errors.As continue to match it.
+func aggregate(err error, wrapped []error) *AggregateError {
+	var (
+		branches = make([]error, 0, len(wrapped))
+		messages = make([]string, 0, len(wrapped))
+	)
+	for _, branch := range wrapped {
+		if branch != nil {
+			branches = append(branches, branch)
+			messages = append(messages, branch.Error())
+		}
+	}
+
+	e := &AggregateError{errs: branches, err: err}
+	if msg := err.Error(); msg != strings.Join(messages, "\n") {
+		e.message = msg
+	}
+
+	return e
+}
+
+// AggregateError holds independent branches of errors. Unlike other errors provided by this package, its node carries
+// the nodes of each branch in Children, and data only when it has a message of its own.
+type AggregateError struct {
+	errs    []error
+	message string
+	err     error
+}
+
+// Errors returns the branches.
+func (e *AggregateError) Errors() []error {
+	return e.errs
+}
+
+// Error returns the message of the error converted into the aggregate typed error, if any, or else joins the messages
+// of the branches with semicolons.
+func (e *AggregateError) Error() string {
+	if len(e.message) > 0 {
+		return e.message
+	}
+	if len(e.errs) == 0 {
+		return "aggregate: <empty>"
+	}
+
+	messages := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e *AggregateError) Unwrap() []error {
+	return e.errs
+}
+
+// Is reports whether the target is the error converted into the aggregate typed error, or matches it by its own Is
+// method. The branches are consulted by errors.Is through Unwrap.
+func (e *AggregateError) Is(target error) bool {
+	if e.err == nil {
+		return false
+	}
+	if reflect.TypeOf(e.err).Comparable() && e.err == target {
+		return true
+	}
+	if x, ok := e.err.(interface{ Is(error) bool }); ok {
+		return x.Is(target)
+	}
+	return false
+}
+
+// As sets the target to the error converted into the aggregate typed error if it is assignable, or else defers to its
+// own As method. The branches are consulted by errors.As through Unwrap.
+func (e *AggregateError) As(target interface{}) bool {
+	if e.err == nil {
+		return false
+	}
+
+	val := reflect.ValueOf(target)
+	if val.Kind() == reflect.Ptr && !val.IsNil() && reflect.TypeOf(e.err).AssignableTo(val.Type().Elem()) {
+		val.Elem().Set(reflect.ValueOf(e.err))
+		return true
+	}
+
+	if x, ok := e.err.(interface{ As(interface{}) bool }); ok {
+		return x.As(target)
+	}
+
+	return false
+}
+
+func (e *AggregateError) MarshalNode() (*Node, error) {
+	children := make([][]*Node, 0, len(e.errs))
+	for _, err := range e.errs {
+		nodes, collectErr := collectNodes(err)
+		if collectErr != nil {
+			return nil, collectErr
+		}
+		children = append(children, nodes)
+	}
+
+	n := &Node{
+		Type:     TypeAggregate,
+		Children: children,
+	}
+
+	if len(e.message) > 0 {
+		jsonBytes, err := json.Marshal(aggregateErrorJSON{Message: e.message})
+		if err != nil {
+			return nil, err
+		}
+		n.Data = jsonBytes
+	}
+
+	return n, nil
+}
+
+// UnmarshalJSON restores the message, as the branches are carried by the children of the node and restored by
+// FromView.
+func (e *AggregateError) UnmarshalJSON(bytes []byte) error {
+	var temp aggregateErrorJSON
+	if err := json.Unmarshal(bytes, &temp); err != nil {
+		return err
+	}
+
+	e.message = temp.Message
+
+	return nil
+}
+
+type aggregateErrorJSON struct {
+	Message string `json:"message,omitempty"`
+}
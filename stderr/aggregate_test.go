@@ -0,0 +1,195 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestJoin(t *testing.T) {
+	var (
+		sentinel = errors.New("sql: no rows in result set")
+		err      = stderr.Chain(
+			stderr.Status(400),
+			stderr.Code("invalid_batch"),
+			stderr.Join(
+				stderr.Chain(stderr.Code("invalid_name"), stderr.Params("index", 0)),
+				nil,
+				stderr.Chain(stderr.Code("invalid_email"), sentinel),
+			),
+		)
+	)
+
+	cases := []struct {
+		target error
+		is     bool
+	}{
+		{target: stderr.Status(400), is: true},
+		{target: stderr.Code("invalid_batch"), is: true},
+		{target: stderr.Code("invalid_name"), is: true},
+		{target: stderr.Code("invalid_email"), is: true},
+		{target: sentinel, is: true},
+		{target: stderr.Code("invalid_phone"), is: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.target.Error(), func(t *testing.T) {
+			if expect, actual := c.is, errors.Is(err, c.target); expect != actual {
+				t.Errorf("expect %v, actual %v", expect, actual)
+			}
+		})
+	}
+
+	var aggregate *stderr.AggregateError
+	if !errors.As(err, &aggregate) {
+		t.Fatal("expect aggregate error in chain")
+	}
+	if expect, actual := 2, len(aggregate.Errors()); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	var params *stderr.ParamsError
+	if !errors.As(err, &params) {
+		t.Error("expect params error in branch")
+	}
+
+	if stderr.Join(nil, nil) != nil {
+		t.Error("expect nil when all errors are nil")
+	}
+}
+
+func TestJoin_View(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(400),
+		stderr.Code("invalid_batch"),
+		errors.Join(
+			stderr.Chain(stderr.Code("invalid_name"), errors.New("name is empty")),
+			stderr.Chain(stderr.Code("invalid_email"), stderr.Message("email is malformed")),
+		),
+	)
+
+	jsonBytes, jsonErr := json.Marshal(stderr.ToView(err))
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	view := new(stderr.View)
+	if jsonErr = json.Unmarshal(jsonBytes, view); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	if expect, actual := 3, len(view.Context); expect != actual {
+		t.Fatalf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := stderr.TypeAggregate, view.Context[2].Type; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := 2, len(view.Context[2].Children); expect != actual {
+		t.Fatalf("expect %v, actual %v", expect, actual)
+	}
+
+	restored := stderr.FromView(view)
+	for _, target := range []error{
+		stderr.Status(400),
+		stderr.Code("invalid_batch"),
+		stderr.Code("invalid_name"),
+		stderr.Code("invalid_email"),
+	} {
+		if !errors.Is(restored, target) {
+			t.Errorf("expect %v in restored chain", target)
+		}
+	}
+
+	public := view.Apply(stderr.PublicPolicy())
+	for _, child := range public.Context[2].Children {
+		for _, n := range child {
+			if n.Type == stderr.TypeGeneric {
+				t.Error("expect generic error in branch to be hidden")
+			}
+		}
+	}
+	if strings.Contains(string(mustMarshal(t, public)), "name is empty") {
+		t.Error("expect generic error text to be hidden")
+	}
+}
+
+func TestJoin_WrapperMessage(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(500),
+		fmt.Errorf("load %s: %w, %w", "config.yaml", errors.New("file not found"), errors.New("env not set")),
+	)
+
+	if expect, actual := 0, len(stderr.ToView(stderr.Chain(stderr.Status(500), errors.Join(errors.New("a"), errors.New("b")))).Context[1].Data); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	view := new(stderr.View)
+	if jsonErr := json.Unmarshal(mustMarshal(t, stderr.ToView(err)), view); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	var aggregate *stderr.AggregateError
+	if !errors.As(stderr.FromView(view), &aggregate) {
+		t.Fatal("expect aggregate error in restored chain")
+	}
+	if expect, actual := "load config.yaml: file not found, env not set", aggregate.Error(); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := 2, len(aggregate.Errors()); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	if strings.Contains(string(mustMarshal(t, view.Apply(stderr.PublicPolicy()))), "config.yaml") {
+		t.Error("expect aggregate message to be hidden")
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return jsonBytes
+}
+
+type multiError struct {
+	errs []error
+}
+
+func (e *multiError) Error() string {
+	return "multiple errors"
+}
+
+func (e *multiError) Unwrap() []error {
+	return e.errs
+}
+
+func TestJoin_Original(t *testing.T) {
+	var (
+		sentinel = errors.New("foo")
+		joined   = errors.Join(sentinel, errors.New("bar"))
+		multi    = &multiError{errs: []error{sentinel}}
+	)
+
+	if !errors.Is(stderr.Chain(stderr.Status(400), joined), joined) {
+		t.Error("expect joined error to match")
+	}
+	if !errors.Is(stderr.Chain(stderr.Status(400), joined), sentinel) {
+		t.Error("expect branch to match")
+	}
+
+	var target *multiError
+	if !errors.As(stderr.Chain(stderr.Status(400), multi), &target) {
+		t.Fatal("expect multi error to match")
+	}
+	if target != multi {
+		t.Error("expect the original multi error")
+	}
+}
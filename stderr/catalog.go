@@ -0,0 +1,152 @@
+package stderr
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var catalog atomic.Pointer[Catalog]
+
+// SetCatalog sets the global Catalog consulted by View.With and Lookup. Setting nil removes the global Catalog.
+func SetCatalog(c *Catalog) {
+	catalog.Store(c)
+}
+
+// Lookup returns the Definition of the code in the global Catalog.
+func Lookup(code string) (Definition, bool) {
+	if c := catalog.Load(); c != nil {
+		return c.Lookup(code)
+	}
+	return Definition{}, false
+}
+
+// Definition declares an error code, alongside the defaults suggested when only the code is present in an error chain.
+type Definition struct {
+	// Code is the error code. It must meet the error code format.
+	Code string `json:"code" yaml:"code"`
+	// Status is the default HTTP status. Zero means no default.
+	Status int `json:"status,omitempty" yaml:"status,omitempty"`
+	// Message is the default human-readable message, whose placeholders are interpolated with the params in the chain
+	// as described by Interpolate. Empty means no default.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// GRPCCode is the default gRPC code, as in google.golang.org/grpc/codes. Zero means no default.
+	GRPCCode uint32 `json:"grpc_code,omitempty" yaml:"grpc_code,omitempty"`
+	// Description documents the error code for developers.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// NewCatalog creates a Catalog with the definitions. It panics if any definition is invalid, see Catalog.Declare.
+func NewCatalog(definitions ...Definition) *Catalog {
+	c := &Catalog{definitions: map[string]Definition{}}
+	c.Declare(definitions...)
+	return c
+}
+
+// Catalog is the collection of declared error codes. When set as the global Catalog with SetCatalog, View.With fills
+// the missing status and message from the definition of the code in the chain.
+type Catalog struct {
+	// OnUndeclared, if set, is called by View.With with the codes in the chain that are not declared in the Catalog.
+	// As View.With runs whenever a chain is rendered, logged or recorded, each undeclared code is reported only once.
+	// Use Validate to check every chain.
+	OnUndeclared func(code string)
+
+	lock        sync.RWMutex
+	definitions map[string]Definition
+	reported    sync.Map
+}
+
+// Declare adds the definitions to the Catalog. It panics if a code does not meet the error code format, or is
+// already declared.
+func (c *Catalog) Declare(definitions ...Definition) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, d := range definitions {
+		if !ValidCode(d.Code) {
+			panic("code " + d.Code + " does not match error code format")
+		}
+		if _, ok := c.definitions[d.Code]; ok {
+			panic("code " + d.Code + " is already declared")
+		}
+		c.definitions[d.Code] = d
+	}
+}
+
+// Lookup returns the Definition of the code.
+func (c *Catalog) Lookup(code string) (Definition, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	d, ok := c.definitions[code]
+	return d, ok
+}
+
+// Definitions returns all definitions in the Catalog, sorted by code.
+func (c *Catalog) Definitions() []Definition {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	results := make([]Definition, 0, len(c.definitions))
+	for _, d := range c.definitions {
+		results = append(results, d)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Code < results[j].Code
+	})
+
+	return results
+}
+
+// Validate returns an error listing the codes in the chain that are not declared in the Catalog, or nil if all of
+// them are declared.
+func (c *Catalog) Validate(err error) error {
+	undeclared := c.undeclared(err)
+	if len(undeclared) == 0 {
+		return nil
+	}
+	return errors.New("undeclared error codes: " + strings.Join(undeclared, ", "))
+}
+
+func (c *Catalog) undeclared(err error) []string {
+	var results []string
+	for _, each := range elements(err) {
+		if ce, ok := each.(*CodeError); ok {
+			if _, declared := c.Lookup(ce.Code()); !declared {
+				results = append(results, ce.Code())
+			}
+		}
+	}
+	return results
+}
+
+// apply fills the missing status and interpolated message of the View from the definition of its code, and reports the
+// undeclared codes in the chain which have not been reported before.
+func (c *Catalog) apply(v *View, err error) {
+	if c.OnUndeclared != nil {
+		for _, code := range c.undeclared(err) {
+			if _, loaded := c.reported.LoadOrStore(code, struct{}{}); !loaded {
+				c.OnUndeclared(code)
+			}
+		}
+	}
+
+	if len(v.Code) == 0 {
+		return
+	}
+
+	d, ok := c.Lookup(v.Code)
+	if !ok {
+		return
+	}
+
+	if v.Status == 0 {
+		v.Status = d.Status
+	}
+	if len(v.Message) == 0 {
+		v.Message = Interpolate(d.Message, chainParams(err))
+	}
+}
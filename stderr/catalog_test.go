@@ -0,0 +1,88 @@
+package stderr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestCatalog(t *testing.T) {
+	var undeclared []string
+
+	catalog := stderr.NewCatalog(
+		stderr.Definition{Code: "invalid_item", Status: 400, Message: "item is invalid"},
+		stderr.Definition{Code: "item_not_found", Status: 404, Message: "item is not found"},
+		stderr.Definition{Code: "store_closed", Status: 409, Message: "store {id} is closed"},
+	)
+	catalog.OnUndeclared = func(code string) {
+		undeclared = append(undeclared, code)
+	}
+
+	stderr.SetCatalog(catalog)
+	defer stderr.SetCatalog(nil)
+
+	cases := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{
+			name:    "code only",
+			err:     stderr.Chain(stderr.Code("invalid_item"), errors.New("foo")),
+			status:  400,
+			message: "item is invalid",
+		},
+		{
+			name:    "chain takes precedence",
+			err:     stderr.Chain(stderr.Status(410), stderr.Code("item_not_found"), stderr.Message("item is gone")),
+			status:  410,
+			message: "item is gone",
+		},
+		{
+			name:    "template message",
+			err:     stderr.Chain(stderr.Code("store_closed"), stderr.Params("id", 42)),
+			status:  409,
+			message: "store 42 is closed",
+		},
+		{
+			name: "undeclared",
+			err:  stderr.Chain(stderr.Code("unknown"), errors.New("foo")),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_ = stderr.ToView(c.err)
+			view := stderr.ToView(c.err)
+			if expect, actual := c.status, view.Status; expect != actual {
+				t.Errorf("expect %d, actual %d", expect, actual)
+			}
+			if expect, actual := c.message, view.Message; expect != actual {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+		})
+	}
+
+	if len(undeclared) != 1 || undeclared[0] != "unknown" {
+		t.Errorf("expect undeclared code to be reported, got %v", undeclared)
+	}
+
+	if err := catalog.Validate(stderr.Chain(stderr.Code("invalid_item"), stderr.Code("unknown"))); err == nil {
+		t.Error("expect undeclared code to fail validation")
+	}
+	if err := catalog.Validate(stderr.Chain(stderr.Code("invalid_item"), errors.New("foo"))); err != nil {
+		t.Errorf("expect declared codes to pass validation, got %s", err)
+	}
+}
+
+func TestCatalog_Declare(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect duplicate declaration to panic")
+		}
+	}()
+
+	stderr.NewCatalog(stderr.Definition{Code: "foo"}, stderr.Definition{Code: "foo"})
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+var goTemplate = template.Must(template.New("go").Funcs(template.FuncMap{
+	"quote":   strconv.Quote,
+	"comment": comment,
+}).Parse(`// Code generated by stderrgen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- if .UsesTime }}
+	"time"
+{{ end }}
+	"github.com/absurdlab/pkg/stderr"
+)
+
+// Catalog declares all error codes of this package, to be set with stderr.SetCatalog.
+var Catalog = stderr.NewCatalog(
+{{- range .Errors }}
+	stderr.Definition{
+		Code: {{ quote .Code }},
+		{{- if .Status }}
+		Status: {{ .Status }},
+		{{- end }}
+		{{- if .Message }}
+		Message: {{ quote .Message }},
+		{{- end }}
+		{{- if .GRPCCode }}
+		GRPCCode: {{ .GRPCCode }},
+		{{- end }}
+		{{- if .Description }}
+		Description: {{ quote .Description }},
+		{{- end }}
+	},
+{{- end }}
+)
+
+var (
+{{- range .Errors }}
+	// Code{{ .Name }} matches errors with the {{ .Code }} code with errors.Is.
+	Code{{ .Name }} = stderr.Code({{ quote .Code }})
+{{- end }}
+)
+{{ range .Errors }}
+// Err{{ .Name }} returns an error with the {{ .Code }} code.
+{{- if .Description }}
+//
+{{ comment .Description }}
+{{- end }}
+func Err{{ .Name }}({{ range $i, $p := .Params }}{{ if $i }}, {{ end }}{{ $p.Identifier }} {{ $p.GoType }}{{ end }}) error {
+	return stderr.Chain(
+		{{- if .Status }}
+		stderr.Status({{ .Status }}),
+		{{- end }}
+		Code{{ .Name }},
+		{{- if .IsTemplate }}
+		stderr.Template({{ quote .Message }}),
+		{{- else if .Message }}
+		stderr.Message({{ quote .Message }}),
+		{{- end }}
+		{{- if .Params }}
+		stderr.Params({{ range $i, $p := .Params }}{{ if $i }}, {{ end }}{{ quote $p.Name }}, {{ $p.Identifier }}{{ end }}),
+		{{- end }}
+	)
+}
+{{ end }}`))
+
+func generateGo(s *spec) ([]byte, error) {
+	usesTime := false
+	for _, d := range s.Errors {
+		for _, p := range d.Params {
+			if strings.HasPrefix(p.GoType(), "time.") {
+				usesTime = true
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := goTemplate.Execute(buf, struct {
+		*spec
+		UsesTime bool
+	}{spec: s, UsesTime: usesTime}); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+
+	return source, nil
+}
+
+func comment(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace("// " + line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func generateMarkdown(s *spec) []byte {
+	buf := new(bytes.Buffer)
+
+	buf.WriteString("# Error reference\n\n")
+	buf.WriteString("| Code | Status | Message | Description |\n")
+	buf.WriteString("| --- | --- | --- | --- |\n")
+	for _, d := range s.Errors {
+		status := ""
+		if d.Status > 0 {
+			status = strconv.Itoa(d.Status)
+		}
+		fmt.Fprintf(buf, "| `%s` | %s | %s | %s |\n", d.Code, status, markdownCell(d.Message), markdownCell(d.Description))
+	}
+
+	for _, d := range s.Errors {
+		if len(d.Params) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(buf, "\n## %s\n\n", d.Code)
+		buf.WriteString("| Param | Type | Description |\n")
+		buf.WriteString("| --- | --- | --- |\n")
+		for _, p := range d.Params {
+			fmt.Fprintf(buf, "| `%s` | %s | %s |\n", p.Name, p.Type, markdownCell(p.Description))
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\n", " ")
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// openAPIDocument holds the View schema under components, and an example of the View per code.
+type openAPIDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Components struct {
+		Schemas  map[string]interface{}    `yaml:"schemas"`
+		Examples map[string]openAPIExample `yaml:"examples"`
+	} `yaml:"components"`
+}
+
+type openAPIExample struct {
+	Summary     string                 `yaml:"summary,omitempty"`
+	Description string                 `yaml:"description,omitempty"`
+	Value       map[string]interface{} `yaml:"value"`
+}
+
+func generateOpenAPI(s *spec) ([]byte, error) {
+	codes := make([]string, 0, len(s.Errors))
+	for _, d := range s.Errors {
+		codes = append(codes, d.Code)
+	}
+
+	doc := new(openAPIDocument)
+	doc.OpenAPI = "3.1.0"
+	doc.Info.Title = "Error reference"
+	doc.Info.Version = "1"
+	doc.Components.Schemas = map[string]interface{}{
+		"Error": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"status":  map[string]interface{}{"type": "integer"},
+				"error":   map[string]interface{}{"type": "string", "enum": codes},
+				"message": map[string]interface{}{"type": "string"},
+				"context": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"type": map[string]interface{}{"type": "string"},
+							"data": map[string]interface{}{},
+						},
+					},
+				},
+			},
+		},
+	}
+	doc.Components.Examples = map[string]openAPIExample{}
+	for _, d := range s.Errors {
+		value := map[string]interface{}{"error": d.Code}
+		if d.Status > 0 {
+			value["status"] = d.Status
+		}
+		if len(d.Message) > 0 {
+			value["message"] = d.Message
+		}
+		doc.Components.Examples[d.Code] = openAPIExample{
+			Summary:     d.Message,
+			Description: d.Description,
+			Value:       value,
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,110 @@
+// Command stderrgen generates typed error constructors from a YAML error catalog.
+//
+// The catalog lists error definitions, each with a code, and optionally a status, a gRPC code, a message, a
+// description and the params of the error:
+//
+//	package: errs
+//	errors:
+//	  - code: item_not_found
+//	    status: 404
+//	    grpc_code: 5
+//	    message: "item {id} not found in {store}"
+//	    description: The item does not exist in the store.
+//	    params:
+//	      - name: id
+//	        type: string
+//	      - name: store
+//	        type: string
+//
+// For each definition, stderrgen generates a constructor building the chain of status, code, message and params, like
+// ErrItemNotFound(id string, store string) error, and a code sentinel for errors.Is checks, like CodeItemNotFound.
+// Messages with placeholders become stderr.Template. A Catalog variable declaring all definitions is generated as
+// well, to be set with stderr.SetCatalog. Param types are one of string, int, int64, float64, bool, time.Time and
+// time.Duration.
+//
+// Optionally, stderrgen writes a Markdown error reference, and an OpenAPI document holding an error schema and an
+// example per code. Usually, it is invoked with go:generate:
+//
+//	//go:generate go run github.com/absurdlab/pkg/stderr/cmd/stderrgen -in errors.yaml -out errors_gen.go -markdown ERRORS.md
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		in       = flag.String("in", "", "path to the YAML error catalog (required)")
+		out      = flag.String("out", "", "path to the generated Go file (default: input name with _gen.go suffix)")
+		pkg      = flag.String("package", "", "package name of the generated Go file (default: catalog package, or $GOPACKAGE)")
+		markdown = flag.String("markdown", "", "path to the generated Markdown error reference, if any")
+		openapi  = flag.String("openapi", "", "path to the generated OpenAPI error reference, if any")
+	)
+	flag.Parse()
+
+	if len(*in) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*in, *out, *pkg, *markdown, *openapi); err != nil {
+		fmt.Fprintln(os.Stderr, "stderrgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pkg, markdown, openapi string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+
+	s, err := parseSpec(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+
+	switch {
+	case len(pkg) > 0:
+		s.Package = pkg
+	case len(s.Package) > 0:
+	case len(os.Getenv("GOPACKAGE")) > 0:
+		s.Package = os.Getenv("GOPACKAGE")
+	default:
+		return fmt.Errorf("package name is required")
+	}
+
+	if len(out) == 0 {
+		out = strings.TrimSuffix(in, filepath.Ext(in)) + "_gen.go"
+	}
+
+	source, err := generateGo(s)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, source, 0o644); err != nil {
+		return err
+	}
+
+	if len(markdown) > 0 {
+		if err := os.WriteFile(markdown, generateMarkdown(s), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if len(openapi) > 0 {
+		doc, err := generateOpenAPI(s)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(openapi, doc, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
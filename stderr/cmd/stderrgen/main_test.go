@@ -0,0 +1,118 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	var (
+		out      = filepath.Join(dir, "errors_gen.go")
+		markdown = filepath.Join(dir, "ERRORS.md")
+		openapi  = filepath.Join(dir, "openapi.yaml")
+	)
+	if err := run("testdata/errors.yaml", out, "", markdown, openapi); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path   string
+		expect []string
+	}{
+		{
+			path: out,
+			expect: []string{
+				"// Code generated by stderrgen. DO NOT EDIT.",
+				"package errs",
+				`CodeItemNotFound = stderr.Code("item_not_found")`,
+				"func ErrItemNotFound(id string, storeId int) error {",
+				`stderr.Template("item {id} not found in {store_id}"),`,
+				`stderr.Params("id", id, "store_id", storeId),`,
+				"func ErrItemReserved(until time.Time, type_ string) error {",
+				"func ErrInternalError() error {",
+				`stderr.Message("something went wrong"),`,
+				`CodeAuthTokenExpired = stderr.Code("auth.token.expired")`,
+				"func ErrAuthTokenExpired(elapsed time.Duration) error {",
+			},
+		},
+		{
+			path: markdown,
+			expect: []string{
+				"| `item_not_found` | 404 | item {id} not found in {store_id} | The item does not exist in the store. |",
+				"| `store_id` | int |  |",
+			},
+		},
+		{
+			path:   openapi,
+			expect: []string{"openapi: 3.1.0", "item_not_found:"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(filepath.Base(c.path), func(t *testing.T) {
+			data, err := os.ReadFile(c.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, each := range c.expect {
+				if !strings.Contains(string(data), each) {
+					t.Errorf("expect %q in output", each)
+				}
+			}
+		})
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, out, nil, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("expect valid go source, got %s", err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("errs", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("expect generated code to compile, got %s", err)
+	}
+
+	data, err := os.ReadFile(openapi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(data, new(map[string]interface{})); err != nil {
+		t.Errorf("expect valid yaml, got %s", err)
+	}
+}
+
+func TestParseSpec_Invalid(t *testing.T) {
+	cases := []struct {
+		name string
+		yaml string
+	}{
+		{name: "empty", yaml: "package: errs"},
+		{name: "invalid code", yaml: "errors: [{code: 'not a code'}]"},
+		{name: "duplicate code", yaml: "errors: [{code: foo}, {code: foo}]"},
+		{name: "invalid status", yaml: "errors: [{code: foo, status: 999}]"},
+		{name: "unsupported type", yaml: "errors: [{code: foo, params: [{name: id, type: uuid}]}]"},
+		{name: "duplicate identifier", yaml: "errors: [{code: foo, params: [{name: store_id, type: int}, {name: storeId, type: int}]}]"},
+		{name: "package identifier", yaml: "errors: [{code: foo, params: [{name: stderr, type: string}]}]"},
+		{name: "time identifier", yaml: "errors: [{code: foo, params: [{name: time, type: time.Time}]}]"},
+		{name: "undeclared placeholder", yaml: "errors: [{code: foo, message: 'item {id} not found'}]"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := parseSpec([]byte(c.yaml)); err == nil {
+				t.Error("expect error")
+			}
+		})
+	}
+}
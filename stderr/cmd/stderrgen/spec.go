@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/absurdlab/pkg/stderr"
+	"gopkg.in/yaml.v3"
+)
+
+// goTypes maps the param types accepted in the catalog to Go types.
+var goTypes = map[string]string{
+	"string":        "string",
+	"int":           "int",
+	"int64":         "int64",
+	"float64":       "float64",
+	"bool":          "bool",
+	"time.Time":     "time.Time",
+	"time.Duration": "time.Duration",
+}
+
+// imports are the packages imported by the generated code, whose names params must not shadow.
+var imports = map[string]struct{}{
+	"stderr": {},
+	"time":   {},
+}
+
+// spec is the YAML error catalog.
+type spec struct {
+	Package string       `yaml:"package"`
+	Errors  []definition `yaml:"errors"`
+}
+
+// definition is a stderr.Definition with the params of the error.
+type definition struct {
+	stderr.Definition `yaml:",inline"`
+	Params            []param `yaml:"params"`
+}
+
+type param struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+}
+
+func parseSpec(data []byte) (*spec, error) {
+	s := new(spec)
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+
+	if len(s.Errors) == 0 {
+		return nil, errors.New("no errors defined")
+	}
+
+	codes := map[string]struct{}{}
+	names := map[string]string{}
+	for i, d := range s.Errors {
+		if !stderr.ValidCode(d.Code) {
+			return nil, fmt.Errorf("errors[%d]: code %q does not match error code format", i, d.Code)
+		}
+		if _, ok := codes[d.Code]; ok {
+			return nil, fmt.Errorf("errors[%d]: code %s is already defined", i, d.Code)
+		}
+		codes[d.Code] = struct{}{}
+
+		if other, ok := names[d.Name()]; ok {
+			return nil, fmt.Errorf("errors[%d]: code %s and %s generate the same name %s", i, d.Code, other, d.Name())
+		}
+		names[d.Name()] = d.Code
+
+		if d.Status != 0 && (d.Status < 100 || d.Status > 599) {
+			return nil, fmt.Errorf("errors[%d]: status %d is invalid", i, d.Status)
+		}
+
+		declared := map[string]struct{}{}
+		identifiers := map[string]struct{}{}
+		for _, p := range d.Params {
+			if len(p.Name) == 0 {
+				return nil, fmt.Errorf("errors[%d]: param name is required", i)
+			}
+			if _, ok := declared[p.Name]; ok {
+				return nil, fmt.Errorf("errors[%d]: param %s is already defined", i, p.Name)
+			}
+			if !token.IsIdentifier(p.Identifier()) {
+				return nil, fmt.Errorf("errors[%d]: param %s does not make a Go identifier", i, p.Name)
+			}
+			if _, ok := imports[p.Identifier()]; ok {
+				return nil, fmt.Errorf("errors[%d]: param %s shadows the imported package %s", i, p.Name, p.Identifier())
+			}
+			if _, ok := identifiers[p.Identifier()]; ok {
+				return nil, fmt.Errorf("errors[%d]: param %s generates a duplicate Go identifier", i, p.Name)
+			}
+			identifiers[p.Identifier()] = struct{}{}
+			if _, ok := goTypes[p.Type]; !ok {
+				return nil, fmt.Errorf("errors[%d]: param %s has unsupported type %q", i, p.Name, p.Type)
+			}
+			declared[p.Name] = struct{}{}
+		}
+
+		for _, name := range placeholders(d.Message) {
+			if _, ok := declared[name]; !ok {
+				return nil, fmt.Errorf("errors[%d]: message placeholder %s is not a param", i, name)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// Name returns the exported Go name of the code, for instance ItemNotFound for item_not_found.
+func (d definition) Name() string {
+	return camel(d.Code, true)
+}
+
+// IsTemplate returns true if the message has placeholders.
+func (d definition) IsTemplate() bool {
+	return len(placeholders(d.Message)) > 0
+}
+
+// Identifier returns the Go parameter name of the param, for instance storeId for store_id. Keywords are suffixed
+// with an underscore.
+func (p param) Identifier() string {
+	id := camel(p.Name, false)
+	if token.IsKeyword(id) {
+		id += "_"
+	}
+	return id
+}
+
+func (p param) GoType() string {
+	return goTypes[p.Type]
+}
+
+// placeholders returns the names of the {name} and {name:format} placeholders in the text.
+func placeholders(text string) []string {
+	var names []string
+
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		name, _, _ := strings.Cut(text[start+1:end], ":")
+		names = append(names, name)
+
+		text = text[end+1:]
+	}
+
+	return names
+}
+
+func camel(s string, upper bool) string {
+	var sb strings.Builder
+
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, part := range parts {
+		runes := []rune(part)
+		if i > 0 || upper {
+			runes[0] = unicode.ToUpper(runes[0])
+		} else {
+			runes[0] = unicode.ToLower(runes[0])
+		}
+		sb.WriteString(string(runes))
+	}
+
+	return sb.String()
+}
@@ -3,34 +3,87 @@ package stderr
 import (
 	"encoding/json"
 	"regexp"
+	"strings"
 )
 
 var (
-	codeFormat = regexp.MustCompile(`^[A-Za-z]\w*$`)
+	codeFormat    *regexp.Regexp
+	segmentFormat = regexp.MustCompile(`^[A-Za-z]\w*$`)
 )
 
 // Code returns a new code typed error. When placed in a chain of errors, this type of error often
 // suggests the appropriate error code to be displayed in the API response.
 //
-// The code supplied to the function must meet the code format. By default, it requires a string that
-// starts with lowercase or uppercase character, and contains only alphanumeric characters and
-// underscore. The regular expression ^[A-Za-z]\w*$ is used to validate it. The default error code
-// format can be changed by SetErrorCodeFormat.
+// The code supplied to the function must meet the code format. A code consists of one or more segments
+// separated by dots, such as auth.token.expired, where the leading segments act as namespaces which can
+// be matched with CodeNamespace. By default, each segment must start with lowercase or uppercase
+// character, and contain only alphanumeric characters and underscore. The regular expression
+// ^[A-Za-z]\w*$ is used to validate each segment. The segment format can be changed by
+// SetCodeSegmentFormat. Alternatively, SetErrorCodeFormat sets a format which validates the whole code
+// instead of each segment.
 func Code(code string) Error {
-	if len(code) == 0 || !codeFormat.MatchString(code) {
+	if !ValidCode(code) {
 		panic("code does not match error code format")
 	}
 	return &CodeError{code: code}
 }
 
-// SetErrorCodeFormat sets the global error format which guards the Code constructor.
+// ValidCode returns true if the code meets the error code format, and hence can be supplied to Code without panic.
+func ValidCode(code string) bool {
+	if len(code) == 0 {
+		return false
+	}
+	if codeFormat != nil {
+		return codeFormat.MatchString(code)
+	}
+	for _, segment := range strings.Split(code, ".") {
+		if !segmentFormat.MatchString(segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetErrorCodeFormat sets the global error code format which guards the Code constructor. The format validates the
+// whole code, dots included, and takes precedence over the segment format set by SetCodeSegmentFormat. An empty format
+// restores the validation of each segment.
 func SetErrorCodeFormat(format string) {
+	if len(format) == 0 {
+		codeFormat = nil
+		return
+	}
 	codeFormat = regexp.MustCompile(format)
 }
 
+// SetCodeSegmentFormat sets the global format of each dot separated segment of error codes, which guards the Code
+// constructor unless a whole code format is set by SetErrorCodeFormat.
+func SetCodeSegmentFormat(format string) {
+	segmentFormat = regexp.MustCompile(format)
+}
+
+// CodeNamespace returns an error to be used as the target of errors.Is, which matches code typed errors whose code
+// is the namespace itself or lies under it. For instance, CodeNamespace("auth") matches auth.token.expired and
+// auth.denied, but not authz.denied. The namespace may itself be dotted, but must not have empty segments. The
+// returned error is not meant to be placed in a chain.
+func CodeNamespace(namespace string) error {
+	for _, segment := range strings.Split(namespace, ".") {
+		if len(segment) == 0 {
+			panic("code namespace must not have empty segments")
+		}
+	}
+	return &codeNamespace{namespace: namespace}
+}
+
+type codeNamespace struct {
+	namespace string
+}
+
+func (e *codeNamespace) Error() string {
+	return e.namespace + ".*"
+}
+
 type CodeError struct {
 	code string
-	next Error
 }
 
 func (e *CodeError) Code() string {
@@ -41,31 +94,25 @@ func (e *CodeError) Error() string {
 	return e.code
 }
 
-func (e *CodeError) Unwrap() error {
-	return e.next
-}
-
 func (e *CodeError) Is(target error) bool {
 	switch ce := target.(type) {
 	case *CodeError:
 		return ce.code == e.code
+	case *codeNamespace:
+		return e.code == ce.namespace || strings.HasPrefix(e.code, ce.namespace+".")
 	default:
 		return false
 	}
 }
 
-func (e *CodeError) wrap(err Error) {
-	e.next = err
-}
-
-func (e *CodeError) asNode() (*node, error) {
+func (e *CodeError) MarshalNode() (*Node, error) {
 	jsonBytes, err := json.Marshal(codeErrorJSON{Code: e.code})
 	if err != nil {
 		return nil, err
 	}
 
-	return &node{
-		Type: typeCode,
+	return &Node{
+		Type: TypeCode,
 		Data: jsonBytes,
 	}, nil
 }
@@ -0,0 +1,86 @@
+package stderr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestValidCode(t *testing.T) {
+	cases := []struct {
+		code  string
+		valid bool
+	}{
+		{code: "not_found", valid: true},
+		{code: "auth.token.expired", valid: true},
+		{code: "", valid: false},
+		{code: "auth.", valid: false},
+		{code: ".auth", valid: false},
+		{code: "auth..token", valid: false},
+		{code: "auth.1token", valid: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.code, func(t *testing.T) {
+			if expect, actual := c.valid, stderr.ValidCode(c.code); expect != actual {
+				t.Errorf("expect %t, actual %t", expect, actual)
+			}
+		})
+	}
+}
+
+func TestSetErrorCodeFormat(t *testing.T) {
+	stderr.SetErrorCodeFormat(`^[a-z]+\.[a-z]+$`)
+	defer stderr.SetErrorCodeFormat("")
+
+	if !stderr.ValidCode("auth.expired") {
+		t.Error("expect whole code format to match")
+	}
+	if stderr.ValidCode("auth") {
+		t.Error("expect whole code format to reject")
+	}
+}
+
+func TestSetCodeSegmentFormat(t *testing.T) {
+	stderr.SetCodeSegmentFormat(`^[a-z]+$`)
+	defer stderr.SetCodeSegmentFormat(`^[A-Za-z]\w*$`)
+
+	if !stderr.ValidCode("auth.expired") {
+		t.Error("expect segment format to match")
+	}
+	if stderr.ValidCode("auth.token_expired") {
+		t.Error("expect segment format to reject")
+	}
+}
+
+func TestCodeNamespace(t *testing.T) {
+	err := stderr.Chain(stderr.Status(401), stderr.Code("auth.token.expired"))
+
+	cases := []struct {
+		namespace string
+		is        bool
+	}{
+		{namespace: "auth", is: true},
+		{namespace: "auth.token", is: true},
+		{namespace: "auth.token.expired", is: true},
+		{namespace: "au", is: false},
+		{namespace: "auth.tok", is: false},
+		{namespace: "billing", is: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.namespace, func(t *testing.T) {
+			if expect, actual := c.is, errors.Is(err, stderr.CodeNamespace(c.namespace)); expect != actual {
+				t.Errorf("expect %t, actual %t", expect, actual)
+			}
+		})
+	}
+
+	if expect, actual := "auth.token.expired", stderr.ToView(err).Code; expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+	if errors.Is(err, stderr.Code("auth")) {
+		t.Error("expect code to match exactly")
+	}
+}
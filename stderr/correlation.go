@@ -0,0 +1,135 @@
+package stderr
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Correlation identifies the request, trace and span in which an error occurred, so that failures surfacing several
+// hops later can be tied back to their origin.
+type Correlation struct {
+	RequestID string `json:"request_id,omitempty" yaml:"request_id,omitempty"`
+	TraceID   string `json:"trace_id,omitempty" yaml:"trace_id,omitempty"`
+	SpanID    string `json:"span_id,omitempty" yaml:"span_id,omitempty"`
+}
+
+// IsZero returns true if none of the identifiers is set.
+func (c Correlation) IsZero() bool {
+	return len(c.RequestID) == 0 && len(c.TraceID) == 0 && len(c.SpanID) == 0
+}
+
+type correlationKey struct{}
+
+// WithCorrelation returns a copy of the context carrying the Correlation.
+func WithCorrelation(ctx context.Context, c Correlation) context.Context {
+	return context.WithValue(ctx, correlationKey{}, c)
+}
+
+// CorrelationFromContext returns the Correlation carried by the context, or a zero Correlation if there is none.
+func CorrelationFromContext(ctx context.Context) Correlation {
+	c, _ := ctx.Value(correlationKey{}).(Correlation)
+	return c
+}
+
+// ParseTraceparent parses the value of a W3C Trace Context traceparent header into a Correlation holding the trace ID
+// and the parent span ID. It returns false if the value is malformed.
+func ParseTraceparent(header string) (Correlation, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return Correlation{}, false
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	switch {
+	case !isHex(version, 2) || version == "ff":
+		return Correlation{}, false
+	case version == "00" && len(parts) != 4:
+		return Correlation{}, false
+	case !isHex(traceID, 32) || traceID == strings.Repeat("0", 32):
+		return Correlation{}, false
+	case !isHex(spanID, 16) || spanID == strings.Repeat("0", 16):
+		return Correlation{}, false
+	case !isHex(flags, 2):
+		return Correlation{}, false
+	}
+
+	return Correlation{TraceID: traceID, SpanID: spanID}, true
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Correlate returns a correlation typed error holding the Correlation carried by the context, or nil if the context
+// carries none, so that it can be placed in a chain unconditionally. When placed in a chain of errors, this type of
+// error identifies the request, trace and span in which the error occurred.
+func Correlate(ctx context.Context) Error {
+	c := CorrelationFromContext(ctx)
+	if c.IsZero() {
+		return nil
+	}
+	return &CorrelationError{correlation: c}
+}
+
+type CorrelationError struct {
+	correlation Correlation
+}
+
+func (e *CorrelationError) Correlation() Correlation {
+	return e.correlation
+}
+
+func (e *CorrelationError) Is(_ error) bool {
+	return false
+}
+
+func (e *CorrelationError) Error() string {
+	var pairs []string
+	if len(e.correlation.RequestID) > 0 {
+		pairs = append(pairs, "request_id="+e.correlation.RequestID)
+	}
+	if len(e.correlation.TraceID) > 0 {
+		pairs = append(pairs, "trace_id="+e.correlation.TraceID)
+	}
+	if len(e.correlation.SpanID) > 0 {
+		pairs = append(pairs, "span_id="+e.correlation.SpanID)
+	}
+
+	if len(pairs) == 0 {
+		return "correlation: <empty>"
+	}
+
+	return "correlation: " + strings.Join(pairs, ", ")
+}
+
+func (e *CorrelationError) MarshalNode() (*Node, error) {
+	jsonBytes, err := json.Marshal(e.correlation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Type: TypeCorrelation,
+		Data: jsonBytes,
+	}, nil
+}
+
+func (e *CorrelationError) UnmarshalJSON(bytes []byte) error {
+	var temp Correlation
+	if err := json.Unmarshal(bytes, &temp); err != nil {
+		return err
+	}
+
+	e.correlation = temp
+
+	return nil
+}
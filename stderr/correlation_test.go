@@ -0,0 +1,100 @@
+package stderr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	cases := []struct {
+		header string
+		expect stderr.Correlation
+		ok     bool
+	}{
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expect: stderr.Correlation{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"},
+			ok:     true,
+		},
+		{
+			header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future",
+			expect: stderr.Correlation{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"},
+			ok:     true,
+		},
+		{header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		{header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{header: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{header: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.header, func(t *testing.T) {
+			actual, ok := stderr.ParseTraceparent(c.header)
+			if c.ok != ok {
+				t.Errorf("expect %v, actual %v", c.ok, ok)
+			}
+			if c.expect != actual {
+				t.Errorf("expect %v, actual %v", c.expect, actual)
+			}
+		})
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	if stderr.Correlate(context.Background()) != nil {
+		t.Error("expect nil without correlation in context")
+	}
+
+	correlation := stderr.Correlation{
+		RequestID: "req-1",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:    "00f067aa0ba902b7",
+	}
+	ctx := stderr.WithCorrelation(context.Background(), correlation)
+
+	err := stderr.Chain(stderr.Status(502), stderr.Correlate(ctx), errors.New("upstream is down"))
+
+	jsonBytes, jsonErr := json.Marshal(stderr.ToView(err))
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	view := new(stderr.View)
+	if jsonErr = json.Unmarshal(jsonBytes, view); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	restored := stderr.FromView(view)
+
+	var ce *stderr.CorrelationError
+	if !errors.As(restored, &ce) {
+		t.Fatal("expect correlation error in restored chain")
+	}
+	if expect, actual := correlation, ce.Correlation(); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	buf := new(bytes.Buffer)
+	slog.New(slog.NewJSONHandler(buf, nil)).Error("request failed", "err", restored)
+
+	var entry struct {
+		Err map[string]interface{} `json:"err"`
+	}
+	if e := json.Unmarshal(buf.Bytes(), &entry); e != nil {
+		t.Fatal(e)
+	}
+	if expect, actual := "req-1", entry.Err["request_id"]; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := correlation.TraceID, entry.Err["trace_id"]; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}
@@ -0,0 +1,72 @@
+package stderr
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Printing and logging are implemented once on the chain, by link.Format and link.LogValue. Every error type provided
+// by this package delegates to them as a chain of one, so that a single error prints and logs like any chain. New
+// types must be added to the assertions below, which fail to compile when a delegate is missing.
+var (
+	_ element = (*StatusError)(nil)
+	_ element = (*CodeError)(nil)
+	_ element = (*MessageError)(nil)
+	_ element = (*TemplateError)(nil)
+	_ element = (*MessageKeyError)(nil)
+	_ element = (*ParamsError)(nil)
+	_ element = (*ViolationsError)(nil)
+	_ element = (*RetryableError)(nil)
+	_ element = (*CorrelationError)(nil)
+	_ element = (*GenericError)(nil)
+	_ element = (*StackError)(nil)
+	_ element = (*AggregateError)(nil)
+)
+
+// element is implemented by every error type provided by this package.
+type element interface {
+	Error
+	fmt.Formatter
+	slog.LogValuer
+}
+
+// single returns the chain of the one error.
+func single(err Error) *link {
+	return &link{err: err}
+}
+
+func (e *StatusError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *StatusError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *CodeError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *CodeError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *MessageError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *MessageError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *TemplateError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *TemplateError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *MessageKeyError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *MessageKeyError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *ParamsError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *ParamsError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *ViolationsError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *ViolationsError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *RetryableError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *RetryableError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *CorrelationError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *CorrelationError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *GenericError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *GenericError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *StackError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *StackError) LogValue() slog.Value          { return single(e).LogValue() }
+
+func (e *AggregateError) Format(s fmt.State, verb rune) { single(e).Format(s, verb) }
+func (e *AggregateError) LogValue() slog.Value          { return single(e).LogValue() }
@@ -2,54 +2,98 @@ package stderr
 
 import "encoding/json"
 
+// Node types of the errors provided by this package, as they appear in View.Context.
 const (
-	typeStatus  = "status"
-	typeCode    = "code"
-	typeMessage = "message"
-	typeParams  = "params"
-	typeGeneric = "generic"
+	TypeStatus      = "status"
+	TypeCode        = "code"
+	TypeMessage     = "message"
+	TypeMessageKey  = "message_key"
+	TypeParams      = "params"
+	TypeGeneric     = "generic"
+	TypeStack       = "stack"
+	TypeAggregate   = "aggregate"
+	TypeViolations  = "violations"
+	TypeRetryable   = "retryable"
+	TypeCorrelation = "correlation"
+	TypeTemplate    = "template"
 )
 
-// Error is the standard interface implemented by errors provided by this package.
-type Error interface {
+// Error is the interface implemented by errors provided by this package. It is an alias of Marshaler, as elements of
+// a chain do not hold references to each other, and errors defined outside this package take part in chains alike.
+type Error = Marshaler
+
+// Marshaler is implemented by errors which, when placed in a chain of errors, describe themselves as a context node
+// in View, instead of being flattened into a generic typed error. The node type must be registered with RegisterType
+// for FromView to restore the error.
+type Marshaler interface {
 	error
 	json.Unmarshaler
-	wrap(err Error)
-	asNode() (*node, error)
-	Unwrap() error
-	Is(target error) bool
+	MarshalNode() (*Node, error)
 }
 
-// Chain wraps the supplied errors in sequence and returns the first error. Errors that implement the Error
-// interface are wrapped as is, other errors are normalized into a generic typed error first.
+// Chain wraps the supplied errors in sequence and returns an error representing the whole chain. Errors that
+// implement the Error interface are wrapped as is, other errors are normalized into a generic typed error first. Nil
+// errors are skipped, and chains are flattened into the new chain.
+//
+// Chain never modifies the supplied errors, so the same error, for instance a package level sentinel, can be safely
+// placed in multiple chains, concurrently. The returned error reports the message of the first error, and exposes
+// every error in the chain to errors.Is and errors.As in sequence.
 func Chain(errors ...error) error {
-	if len(errors) == 0 {
-		return nil
-	}
-
-	var head = normalize(errors[0])
-	if len(errors) == 1 {
-		return head
-	}
+	var result error
 
-	var cursor = head
-	for i := 1; i < len(errors); i++ {
+	for i := len(errors) - 1; i >= 0; i-- {
 		this := normalize(errors[i])
-		cursor.wrap(this)
-		cursor = this
+		if this == nil {
+			continue
+		}
+
+		if result == nil {
+			result = this
+		} else {
+			result = &link{err: this, next: result}
+		}
 	}
 
-	return head
+	return result
 }
 
-func normalize(err error) Error {
-	if err == nil {
+// link is an immutable cell of the chain, connecting an error to the rest of the chain.
+type link struct {
+	err  error
+	next error
+}
+
+func (l *link) Error() string {
+	return l.err.Error()
+}
+
+func (l *link) Unwrap() []error {
+	return []error{l.err, l.next}
+}
+
+// elements returns the errors in chain in sequence, flattening nested chains.
+func elements(err error) []Error {
+	switch e := err.(type) {
+	case nil:
 		return nil
+	case *link:
+		return append(elements(e.err), elements(e.next)...)
+	default:
+		return []Error{normalize(err).(Error)}
 	}
+}
 
-	if stderr, ok := err.(Error); ok {
-		return stderr
+func normalize(err error) error {
+	if err == nil {
+		return nil
 	}
 
-	return generic(err)
+	switch e := err.(type) {
+	case Error, *link:
+		return err
+	case interface{ Unwrap() []error }:
+		return aggregate(err, e.Unwrap())
+	default:
+		return generic(err)
+	}
 }
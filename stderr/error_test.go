@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/absurdlab/pkg/stderr"
+	"sync"
 	"testing"
 )
 
@@ -34,7 +35,7 @@ func TestChain_Is(t *testing.T) {
 		{
 			err:    stderr.Chain(foo, codeNotFound, bar),
 			target: bar,
-			is:     false,
+			is:     true,
 		},
 		{
 			err:    stderr.Chain(foo, codeNotFound, messageHello),
@@ -129,6 +130,61 @@ func TestChain_As(t *testing.T) {
 	}
 }
 
+func TestChain_Immutable(t *testing.T) {
+	var (
+		sentinel = stderr.Code("sentinel")
+		foo      = errors.New("foo")
+		bar      = errors.New("bar")
+	)
+
+	first := stderr.Chain(sentinel, foo)
+	second := stderr.Chain(sentinel, bar)
+	nested := stderr.Chain(stderr.Status(400), first, bar)
+
+	if !errors.Is(first, foo) || errors.Is(first, bar) {
+		t.Error("expect first chain to be unaffected by second chain")
+	}
+	if !errors.Is(second, bar) || errors.Is(second, foo) {
+		t.Error("expect second chain to contain its own errors")
+	}
+	if !errors.Is(nested, sentinel) || !errors.Is(nested, foo) || !errors.Is(nested, bar) {
+		t.Error("expect nested chain to contain all errors")
+	}
+	if errors.Is(first, bar) {
+		t.Error("expect first chain to be unaffected by nested chain")
+	}
+
+	if expect, actual := 4, len(stderr.ToView(nested).Context); expect != actual {
+		t.Errorf("expect %d, actual %d", expect, actual)
+	}
+}
+
+func TestChain_Concurrent(t *testing.T) {
+	var (
+		sentinel = stderr.Code("sentinel")
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			cause := fmt.Errorf("cause %d", i)
+			for j := 0; j < 100; j++ {
+				err := stderr.Chain(stderr.Status(400), sentinel, stderr.ErrCorruptedView, cause)
+				if !errors.Is(err, cause) {
+					t.Errorf("expect chain to retain its own cause")
+					return
+				}
+				_ = stderr.ToView(err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
 type customError struct {
 	e string
 }
@@ -0,0 +1,102 @@
+package stderr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// FingerprintOption configures Fingerprint.
+type FingerprintOption func(c *fingerprintConfig)
+
+// WithFrames provides a FingerprintOption to include the functions of the stack frames in the fingerprint, so that
+// chains of the same shape originating from different call sites are told apart. File names and line numbers are
+// left out, as they change with every edit of the source.
+func WithFrames() FingerprintOption {
+	return func(c *fingerprintConfig) {
+		c.frames = true
+	}
+}
+
+type fingerprintConfig struct {
+	frames bool
+}
+
+// Fingerprint returns the hex encoded SHA-256 hash of the shape of the chain, which is stable across occurrences of
+// the same failure, and hence suits grouping and rate limiting errors. The shape consists of the node types in order,
+// the statuses, the codes, the param keys, the templates, the message keys, the fields and rules of violations, the
+// backoff strategies of retryable errors, the sentinel identifiers of generic errors, and the shapes of the branches
+// of aggregate errors. Messages, param values and rejected values are left out, as they vary with the interpolated
+// values. It returns an empty string if the error is nil.
+func Fingerprint(err error, options ...FingerprintOption) string {
+	if err == nil {
+		return ""
+	}
+
+	c := new(fingerprintConfig)
+	for _, opt := range options {
+		opt(c)
+	}
+
+	h := sha256.New()
+	c.write(h, err)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// write writes the shape of the chain to the hash, one line per element.
+func (c *fingerprintConfig) write(h hash.Hash, err error) {
+	for _, each := range elements(err) {
+		switch e := each.(type) {
+		case *StatusError:
+			_, _ = io.WriteString(h, TypeStatus+":"+strconv.Itoa(e.Status())+"\n")
+		case *CodeError:
+			_, _ = io.WriteString(h, TypeCode+":"+e.Code()+"\n")
+		case *ParamsError:
+			keys := make([]string, 0, len(e.Params()))
+			for k := range e.Params() {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			_, _ = fmt.Fprintf(h, "%s:%q\n", TypeParams, keys)
+		case *TemplateError:
+			_, _ = fmt.Fprintf(h, "%s:%q\n", TypeTemplate, e.Template())
+		case *MessageKeyError:
+			_, _ = fmt.Fprintf(h, "%s:%q\n", TypeMessageKey, e.Key())
+		case *ViolationsError:
+			_, _ = io.WriteString(h, TypeViolations+"\n")
+			for _, v := range e.Violations() {
+				_, _ = fmt.Fprintf(h, "\t%q:%q\n", v.Field, v.Rule)
+			}
+		case *RetryableError:
+			_, _ = fmt.Fprintf(h, "%s:%s\n", TypeRetryable, e.Backoff())
+		case *GenericError:
+			id, _ := sentinelID(e.err)
+			_, _ = io.WriteString(h, TypeGeneric+":"+id+"\n")
+		case *StackError:
+			_, _ = io.WriteString(h, TypeStack+"\n")
+			if c.frames {
+				for _, f := range e.Frames() {
+					_, _ = io.WriteString(h, "\t"+f.Function+"\n")
+				}
+			}
+		case *AggregateError:
+			_, _ = fmt.Fprintf(h, "%s:%d\n", TypeAggregate, len(e.Errors()))
+			for _, branch := range e.Errors() {
+				_, _ = io.WriteString(h, "[\n")
+				c.write(h, branch)
+				_, _ = io.WriteString(h, "]\n")
+			}
+		default:
+			if n, nodeErr := e.MarshalNode(); nodeErr == nil && n != nil {
+				_, _ = io.WriteString(h, n.Type+"\n")
+			} else {
+				_, _ = fmt.Fprintf(h, "%T\n", e)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package stderr_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestFingerprint(t *testing.T) {
+	notFound := func(id string) error {
+		return stderr.Chain(
+			stderr.Status(404),
+			stderr.Code("not_found"),
+			stderr.Message("item "+id+" is not found"),
+			stderr.Params("id", id),
+			errors.New("sql: no rows for "+id),
+		)
+	}
+
+	cases := []struct {
+		name  string
+		a     error
+		b     error
+		equal bool
+	}{
+		{
+			name:  "different values",
+			a:     notFound("1"),
+			b:     notFound("2"),
+			equal: true,
+		},
+		{
+			name:  "different status",
+			a:     notFound("1"),
+			b:     stderr.Chain(stderr.Status(410), stderr.Code("not_found"), stderr.Params("id", "1"), errors.New("x")),
+			equal: false,
+		},
+		{
+			name:  "different param keys",
+			a:     stderr.Chain(stderr.Code("not_found"), stderr.Params("id", "1")),
+			b:     stderr.Chain(stderr.Code("not_found"), stderr.Params("name", "1")),
+			equal: false,
+		},
+		{
+			name:  "different sentinel",
+			a:     stderr.Chain(stderr.Code("read_failed"), io.EOF),
+			b:     stderr.Chain(stderr.Code("read_failed"), io.ErrUnexpectedEOF),
+			equal: false,
+		},
+		{
+			name:  "different aggregate branches",
+			a:     stderr.Join(stderr.Code("a"), stderr.Code("b")),
+			b:     stderr.Join(stderr.Chain(stderr.Code("a"), stderr.Code("b"))),
+			equal: false,
+		},
+		{
+			name:  "different violations",
+			a:     stderr.Violations(stderr.Violation{Field: "email", Rule: "email", Value: "foo"}),
+			b:     stderr.Violations(stderr.Violation{Field: "name", Rule: "required"}),
+			equal: false,
+		},
+		{
+			name:  "different rejected values",
+			a:     stderr.Violations(stderr.Violation{Field: "email", Rule: "email", Value: "foo"}),
+			b:     stderr.Violations(stderr.Violation{Field: "email", Rule: "email", Value: "bar"}),
+			equal: true,
+		},
+		{
+			name:  "different templates",
+			a:     stderr.Chain(stderr.Code("failed"), stderr.Template("item {id} missing")),
+			b:     stderr.Chain(stderr.Code("failed"), stderr.Template("store {id} missing")),
+			equal: false,
+		},
+		{
+			name:  "different message keys",
+			a:     stderr.Chain(stderr.Code("failed"), stderr.MessageKey("item.missing")),
+			b:     stderr.Chain(stderr.Code("failed"), stderr.MessageKey("store.missing")),
+			equal: false,
+		},
+		{
+			name:  "stack frames ignored",
+			a:     stackFromA(),
+			b:     stackFromB(),
+			equal: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if expect, actual := c.equal, stderr.Fingerprint(c.a) == stderr.Fingerprint(c.b); expect != actual {
+				t.Errorf("expect %t, actual %t", expect, actual)
+			}
+		})
+	}
+
+	if stderr.Fingerprint(stackFromA(), stderr.WithFrames()) == stderr.Fingerprint(stackFromB(), stderr.WithFrames()) {
+		t.Error("expect frames to tell call sites apart")
+	}
+	if expect, actual := "", stderr.Fingerprint(nil); expect != actual {
+		t.Errorf("expect %q, actual %q", expect, actual)
+	}
+}
+
+func stackFromA() error {
+	return stderr.Chain(stderr.Code("failed"), stderr.Stack())
+}
+
+func stackFromB() error {
+	return stderr.Chain(stderr.Code("failed"), stderr.Stack())
+}
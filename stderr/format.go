@@ -0,0 +1,156 @@
+package stderr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter. The %v verb prints a one-line summary of the chain, made of the first status and
+// code, the message, and the generic causes, like "status=400 code=invalid_item: item is invalid: sql: no rows in
+// result set". The %+v verb prints every error in the chain on its own line, along with params, violations and stack
+// frames, with the branches of aggregate typed errors indented beneath. Other verbs print the error message.
+func (l *link) Format(s fmt.State, verb rune) {
+	format(l, s, verb)
+}
+
+func format(err error, s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		writeTree(s, err, "")
+	case verb == 'v':
+		_, _ = fmt.Fprintf(s, fmt.FormatString(s, 's'), summary(err))
+	default:
+		_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), err.Error())
+	}
+}
+
+// summary returns the one-line summary of the chain. It falls back to the error message if the chain has no status,
+// code, message or generic cause.
+func summary(err error) string {
+	var (
+		labels []string
+		parts  []string
+		status *StatusError
+		code   *CodeError
+	)
+
+	for _, each := range elements(err) {
+		switch e := each.(type) {
+		case *StatusError:
+			if status == nil {
+				status = e
+				labels = append(labels, "status="+strconv.Itoa(e.Status()))
+			}
+		case *CodeError:
+			if code == nil {
+				code = e
+				labels = append(labels, "code="+e.Code())
+			}
+		case *GenericError:
+			parts = append(parts, e.Error())
+		case *AggregateError:
+			if len(e.message) > 0 {
+				parts = append(parts, e.message)
+				continue
+			}
+			branches := make([]string, 0, len(e.Errors()))
+			for _, branch := range e.Errors() {
+				branches = append(branches, summary(branch))
+			}
+			parts = append(parts, "["+strings.Join(branches, "; ")+"]")
+		}
+	}
+
+	if m := message(err); len(m) > 0 {
+		parts = append([]string{m}, parts...)
+	}
+
+	if len(labels) > 0 {
+		parts = append([]string{strings.Join(labels, " ")}, parts...)
+	}
+
+	if len(parts) == 0 {
+		return err.Error()
+	}
+
+	return strings.Join(parts, ": ")
+}
+
+// writeTree writes every error in the chain on its own line, prefixed by indent.
+func writeTree(w io.Writer, err error, indent string) {
+	for i, each := range elements(err) {
+		if i > 0 {
+			_, _ = io.WriteString(w, "\n")
+		}
+		_, _ = io.WriteString(w, indent)
+
+		switch e := each.(type) {
+		case *StatusError:
+			_, _ = fmt.Fprintf(w, "%s: %d", TypeStatus, e.Status())
+		case *CodeError:
+			_, _ = fmt.Fprintf(w, "%s: %s", TypeCode, e.Code())
+		case *MessageError:
+			_, _ = fmt.Fprintf(w, "%s: %s", TypeMessage, e.Message())
+		case *TemplateError:
+			_, _ = fmt.Fprintf(w, "%s: %s", TypeTemplate, e.Template())
+		case *MessageKeyError:
+			_, _ = fmt.Fprintf(w, "%s: %s", TypeMessageKey, e.Key())
+		case *ParamsError:
+			_, _ = io.WriteString(w, TypeParams+":")
+			keys := make([]string, 0, len(e.Params()))
+			for k := range e.Params() {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				_, _ = fmt.Fprintf(w, "\n%s  %s: %v", indent, k, e.Params()[k])
+			}
+		case *ViolationsError:
+			_, _ = io.WriteString(w, TypeViolations+":")
+			for _, v := range e.Violations() {
+				_, _ = fmt.Fprintf(w, "\n%s  %s: %s", indent, v.Field, v.Rule)
+				if len(v.Message) > 0 {
+					_, _ = fmt.Fprintf(w, ", %s", v.Message)
+				}
+				if v.Value != nil {
+					_, _ = fmt.Fprintf(w, " (value: %v)", v.Value)
+				}
+			}
+		case *RetryableError:
+			_, _ = io.WriteString(w, TypeRetryable)
+			if e.RetryAfter() > 0 {
+				_, _ = fmt.Fprintf(w, ": after %s", e.RetryAfter())
+			}
+			if len(e.Backoff()) > 0 {
+				_, _ = fmt.Fprintf(w, ", %s backoff", e.Backoff())
+			}
+		case *CorrelationError:
+			_, _ = io.WriteString(w, e.Error())
+		case *StackError:
+			_, _ = io.WriteString(w, TypeStack+":")
+			for _, f := range e.Frames() {
+				_, _ = fmt.Fprintf(w, "\n%s  %s\n%s      %s:%d", indent, f.Function, indent, f.File, f.Line)
+			}
+		case *AggregateError:
+			_, _ = io.WriteString(w, TypeAggregate+":")
+			if len(e.message) > 0 {
+				_, _ = io.WriteString(w, " "+e.message)
+			}
+			for j, branch := range e.Errors() {
+				_, _ = fmt.Fprintf(w, "\n%s  [%d]\n", indent, j)
+				writeTree(w, branch, indent+"    ")
+			}
+		case *GenericError:
+			_, _ = fmt.Fprintf(w, "%s: %s", TypeGeneric, e.Error())
+		default:
+			if n, nodeErr := e.MarshalNode(); nodeErr == nil && n != nil {
+				_, _ = fmt.Fprintf(w, "%s: %v", n.Type, e)
+			} else {
+				_, _ = io.WriteString(w, e.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package stderr_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestChain_Format(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(400),
+		stderr.Code("invalid_item"),
+		stderr.Template("item {id} is invalid"),
+		stderr.Params("id", "42", "count", 3),
+		stderr.Retryable(stderr.WithRetryAfter(time.Second)),
+		stderr.Join(
+			stderr.Chain(stderr.Code("invalid_name"), errors.New("name is empty")),
+			stderr.Violations(stderr.Violation{Field: "email", Rule: "email", Message: "email is malformed"}),
+		),
+		errors.New("sql: no rows in result set"),
+	)
+
+	cases := []struct {
+		format string
+		expect string
+	}{
+		{
+			format: "%v",
+			expect: "status=400 code=invalid_item: item 42 is invalid: [code=invalid_name: name is empty; violations: email]: sql: no rows in result set",
+		},
+		{
+			format: "%s",
+			expect: "status: 400",
+		},
+		{
+			format: "%q",
+			expect: `"status: 400"`,
+		},
+		{
+			format: "%+v",
+			expect: strings.Join([]string{
+				"status: 400",
+				"code: invalid_item",
+				"template: item {id} is invalid",
+				"params:",
+				"  count: 3",
+				"  id: 42",
+				"retryable: after 1s",
+				"aggregate:",
+				"  [0]",
+				"    code: invalid_name",
+				"    generic: name is empty",
+				"  [1]",
+				"    violations:",
+				"      email: email, email is malformed",
+				"generic: sql: no rows in result set",
+			}, "\n"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			if actual := fmt.Sprintf(c.format, err); c.expect != actual {
+				t.Errorf("expect %s, actual %s", c.expect, actual)
+			}
+		})
+	}
+}
+
+func TestError_Format(t *testing.T) {
+	cases := []struct {
+		err    error
+		expect string
+	}{
+		{err: stderr.Status(404), expect: "status=404"},
+		{err: stderr.Code("not_found"), expect: "code=not_found"},
+		{err: stderr.Message("item is not found"), expect: "item is not found"},
+		{err: stderr.Params("id", "42"), expect: "params: id"},
+		{err: stderr.Chain(stderr.Message("item is not found"), errors.New("boom")), expect: "item is not found: boom"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expect, func(t *testing.T) {
+			if actual := fmt.Sprintf("%v", c.err); c.expect != actual {
+				t.Errorf("expect %s, actual %s", c.expect, actual)
+			}
+		})
+	}
+}
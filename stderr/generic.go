@@ -13,16 +13,7 @@ func generic(err error) *GenericError {
 }
 
 type GenericError struct {
-	err  error
-	next Error
-}
-
-func (e *GenericError) Is(err error) bool {
-	return errors.Is(e.err, err)
-}
-
-func (e *GenericError) As(target interface{}) bool {
-	return errors.As(e.err, target)
+	err error
 }
 
 func (e *GenericError) Error() string {
@@ -30,21 +21,20 @@ func (e *GenericError) Error() string {
 }
 
 func (e *GenericError) Unwrap() error {
-	return e.next
+	return e.err
 }
 
-func (e *GenericError) wrap(err Error) {
-	e.next = err
-}
+func (e *GenericError) MarshalNode() (*Node, error) {
+	temp := genericErrorJSON{Error: e.Error()}
+	temp.Sentinel, _ = sentinelID(e.err)
 
-func (e *GenericError) asNode() (*node, error) {
-	jsonBytes, err := json.Marshal(genericErrorJSON{Error: e.Error()})
+	jsonBytes, err := json.Marshal(temp)
 	if err != nil {
 		return nil, err
 	}
 
-	return &node{
-		Type: typeGeneric,
+	return &Node{
+		Type: TypeGeneric,
 		Data: jsonBytes,
 	}, nil
 }
@@ -57,9 +47,33 @@ func (e *GenericError) UnmarshalJSON(bytes []byte) error {
 
 	e.err = errors.New(temp.Error)
 
+	if len(temp.Sentinel) > 0 {
+		if s, ok := lookupSentinel(temp.Sentinel); ok {
+			e.err = s
+			if s.Error() != temp.Error {
+				e.err = &sentinelError{text: temp.Error, sentinel: s}
+			}
+		}
+	}
+
 	return nil
 }
 
 type genericErrorJSON struct {
-	Error string `json:"error"`
+	Error    string `json:"error"`
+	Sentinel string `json:"sentinel,omitempty"`
+}
+
+// sentinelError restores the text of an error wrapping a registered sentinel.
+type sentinelError struct {
+	text     string
+	sentinel error
+}
+
+func (e *sentinelError) Error() string {
+	return e.text
+}
+
+func (e *sentinelError) Unwrap() error {
+	return e.sentinel
 }
@@ -0,0 +1,207 @@
+package grpcerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/grpcerr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestStatus_RoundTrip(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(404),
+		stderr.Code("not_found"),
+		stderr.Message("item is not found"),
+		stderr.Params("id", "42", "count", 3),
+		errors.New("sql: no rows in result set"),
+	)
+
+	st := grpcerr.ToStatus(err, grpcerr.WithDomain("items"))
+	if expect, actual := codes.NotFound, st.Code(); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+	if expect, actual := "item is not found", st.Message(); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+
+	restored := grpcerr.FromStatus(st)
+	assertChain(t, restored)
+
+	var cause *stderr.GenericError
+	if !errors.As(restored, &cause) {
+		t.Fatal("expect generic cause in chain")
+	}
+	if expect, actual := "item is not found", cause.Error(); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+
+	forwarded := grpcerr.FromStatus(grpcerr.ToStatus(restored, grpcerr.WithDomain("items")))
+	if expect, actual := fmt.Sprintf("%v", restored), fmt.Sprintf("%v", forwarded); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+}
+
+func TestToStatus_LargeInteger(t *testing.T) {
+	st := grpcerr.ToStatus(stderr.Chain(stderr.Code("not_found"), stderr.Params("id", int64(1311768467463790321))))
+
+	var params *stderr.ParamsError
+	if !errors.As(grpcerr.FromStatus(st), &params) {
+		t.Fatal("expect params error in chain")
+	}
+	if expect, actual := "1311768467463790321", params.Params()["id"]; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}
+
+func TestToStatus_PassThrough(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: status.Error(codes.Aborted, "aborted"), code: codes.Aborted},
+		{err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+		{err: errors.New("foo"), code: codes.Unknown},
+	}
+
+	for _, c := range cases {
+		t.Run(c.code.String(), func(t *testing.T) {
+			if expect, actual := c.code, grpcerr.ToStatus(c.err).Code(); expect != actual {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+		})
+	}
+}
+
+func TestToStatus_Catalog(t *testing.T) {
+	stderr.SetCatalog(stderr.NewCatalog(stderr.Definition{
+		Code:     "quota_exceeded",
+		Status:   429,
+		Message:  "quota is exceeded",
+		GRPCCode: uint32(codes.ResourceExhausted),
+	}))
+	defer stderr.SetCatalog(nil)
+
+	st := grpcerr.ToStatus(stderr.Chain(stderr.Status(400), stderr.Code("quota_exceeded")))
+	if expect, actual := codes.ResourceExhausted, st.Code(); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+	if expect, actual := "quota is exceeded", st.Message(); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+
+	observed := make(chan codes.Code, 2)
+	observer := grpcerr.WithObserver(func(ctx context.Context, method string, code codes.Code, err error) {
+		observed <- code
+	})
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcerr.UnaryServerInterceptor(observer)),
+		grpc.StreamInterceptor(grpcerr.StreamServerInterceptor(observer)),
+	)
+	grpc_health_v1.RegisterHealthServer(server, &healthServer{})
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(grpcerr.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(grpcerr.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	t.Run("unary", func(t *testing.T) {
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assertChain(t, err)
+
+		if expect, actual := codes.NotFound, <-observed; expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = stream.Recv()
+		assertChain(t, err)
+	})
+}
+
+func assertChain(t *testing.T, err error) {
+	t.Helper()
+
+	if !errors.Is(err, stderr.Status(404)) {
+		t.Error("expect status error in chain")
+	}
+	if !errors.Is(err, stderr.Code("not_found")) {
+		t.Error("expect code error in chain")
+	}
+	if expect, actual := codes.NotFound, status.Code(err); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+
+	var message *stderr.MessageError
+	if !errors.As(err, &message) {
+		t.Error("expect message error in chain")
+	} else if expect, actual := "item is not found", message.Message(); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+
+	var params *stderr.ParamsError
+	if !errors.As(err, &params) {
+		t.Error("expect params error in chain")
+	} else {
+		if expect, actual := "42", params.Params()["id"]; expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+		if expect, actual := "3", params.Params()["count"]; expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+	}
+}
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (s *healthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, notFound()
+}
+
+func (s *healthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer) error {
+	return notFound()
+}
+
+func notFound() error {
+	return stderr.Chain(
+		stderr.Status(404),
+		stderr.Code("not_found"),
+		stderr.Message("item is not found"),
+		stderr.Params("id", "42", "count", 3),
+		errors.New("sql: no rows in result set"),
+	)
+}
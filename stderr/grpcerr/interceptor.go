@@ -0,0 +1,83 @@
+package grpcerr
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that converts errors returned by the handler to gRPC
+// statuses using ToStatus, and notifies the observers supplied with WithObserver.
+func UnaryServerInterceptor(options ...Option) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, convert(ctx, info.FullMethod, err, options)
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor that converts errors returned by the handler to gRPC
+// statuses using ToStatus, and notifies the observers supplied with WithObserver.
+func StreamServerInterceptor(options ...Option) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return convert(ss.Context(), info.FullMethod, err, options)
+		}
+		return nil
+	}
+}
+
+// convert converts the error returned by the handler using ToStatus, and notifies the observers.
+func convert(ctx context.Context, method string, err error, options []Option) error {
+	st := ToStatus(err, options...)
+	for _, observe := range newConfig(options).observers {
+		observe(ctx, method, st.Code(), err)
+	}
+	return st.Err()
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor that reconstructs error chains from the gRPC statuses
+// returned by the invocation using FromError.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns a grpc.StreamClientInterceptor that reconstructs error chains from the gRPC
+// statuses returned by the stream using FromError. io.EOF is returned as is to signal the end of stream.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+		return &clientStream{ClientStream: cs}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	return convertStreamError(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	return convertStreamError(s.ClientStream.RecvMsg(m))
+}
+
+func (s *clientStream) CloseSend() error {
+	return convertStreamError(s.ClientStream.CloseSend())
+}
+
+func convertStreamError(err error) error {
+	if err == io.EOF {
+		return err
+	}
+	return FromError(err)
+}
@@ -0,0 +1,69 @@
+package grpcerr
+
+import (
+	"context"
+
+	"github.com/absurdlab/pkg/stderr"
+	"google.golang.org/grpc"
+)
+
+// PanicHook is notified of every panic recovered by the recovery interceptors, alongside the full method name, the
+// recovered value and the error chain converted from it by stderr.Recovered. It is meant for reporting, like sending
+// the panic to an error tracker.
+type PanicHook func(ctx context.Context, method string, recovered interface{}, err error)
+
+// WithPanicHook provides an Option to notify the PanicHook of every panic recovered by the recovery interceptors. It
+// may be supplied multiple times, in which case the hooks are notified in order. It has no effect on ToStatus.
+func WithPanicHook(hook PanicHook) Option {
+	return func(c *config) {
+		if hook != nil {
+			c.panicHooks = append(c.panicHooks, hook)
+		}
+	}
+}
+
+// UnaryRecoveryInterceptor returns a grpc.UnaryServerInterceptor that recovers panics in the handler, converts the
+// recovered value into an error chain with stderr.Recovered, notifies the hooks supplied with WithPanicHook, and
+// returns the chain converted to a gRPC status like UnaryServerInterceptor does. The chain is converted with
+// stderr.PublicPolicy unless WithPolicy is supplied.
+func UnaryRecoveryInterceptor(options ...Option) grpc.UnaryServerInterceptor {
+	options = recoveryOptions(options)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = recovered(ctx, info.FullMethod, v, stderr.Recovered(v), options)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor returns a grpc.StreamServerInterceptor that recovers panics in the handler, converts the
+// recovered value into an error chain with stderr.Recovered, notifies the hooks supplied with WithPanicHook, and
+// returns the chain converted to a gRPC status like StreamServerInterceptor does. The chain is converted with
+// stderr.PublicPolicy unless WithPolicy is supplied.
+func StreamRecoveryInterceptor(options ...Option) grpc.StreamServerInterceptor {
+	options = recoveryOptions(options)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = recovered(ss.Context(), info.FullMethod, v, stderr.Recovered(v), options)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recoveryOptions defaults the policy to stderr.PublicPolicy, as recovered chains carry the stack and the panic value.
+// Options supplied by the caller are applied afterwards, hence take precedence.
+func recoveryOptions(options []Option) []Option {
+	return append([]Option{WithPolicy(stderr.PublicPolicy())}, options...)
+}
+
+// recovered notifies the hooks of the recovered panic, and converts the error chain using convert.
+func recovered(ctx context.Context, method string, v interface{}, err error, options []Option) error {
+	for _, hook := range newConfig(options).panicHooks {
+		hook(ctx, method, v, err)
+	}
+	return convert(ctx, method, err, options)
+}
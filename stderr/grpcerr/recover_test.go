@@ -0,0 +1,38 @@
+package grpcerr_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/grpcerr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryRecoveryInterceptor(t *testing.T) {
+	var reported interface{}
+	interceptor := grpcerr.UnaryRecoveryInterceptor(grpcerr.WithPanicHook(func(_ context.Context, method string, recovered interface{}, _ error) {
+		reported = recovered
+	}))
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Panic"}, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	if expect, actual := codes.Internal, status.Code(err); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+	if expect, actual := "boom", reported; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if !errors.Is(grpcerr.FromError(err), stderr.Code(stderr.CodeInternalError)) {
+		t.Error("expect code error in chain")
+	}
+	if strings.Contains(status.Convert(err).Proto().String(), "boom") {
+		t.Error("expect panic value to be hidden from the status")
+	}
+}
@@ -0,0 +1,219 @@
+// Package grpcerr converts stderr error chains to and from gRPC statuses, and provides interceptors to apply the
+// conversion automatically.
+package grpcerr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/absurdlab/pkg/stderr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultDomain is the default errdetails.ErrorInfo domain attached to the converted statuses.
+const DefaultDomain = "stderr"
+
+// Option configures the conversion from error chains to gRPC statuses.
+type Option func(c *config)
+
+// WithDomain provides an Option to set the domain of the errdetails.ErrorInfo attached to the converted statuses.
+// It is usually the name of the service producing the error.
+func WithDomain(domain string) Option {
+	return func(c *config) {
+		if len(domain) > 0 {
+			c.domain = domain
+		}
+	}
+}
+
+// WithPolicy provides an Option to apply the stderr.Policy to the context nodes before params are collected into
+// the errdetails.ErrorInfo metadata.
+func WithPolicy(policy *stderr.Policy) Option {
+	return func(c *config) {
+		c.policy = policy
+	}
+}
+
+// Observer is notified of every error returned by the handlers of the server interceptors, alongside the full
+// method name and the gRPC code of the converted status. It is meant for instrumentation, like recording the error on
+// a trace span or counting errors by code.
+type Observer func(ctx context.Context, method string, code codes.Code, err error)
+
+// WithObserver provides an Option to notify the Observer of every error returned by the handlers of the server
+// interceptors. It may be supplied multiple times, in which case the observers are notified in order. It has no
+// effect on ToStatus.
+func WithObserver(observer Observer) Option {
+	return func(c *config) {
+		if observer != nil {
+			c.observers = append(c.observers, observer)
+		}
+	}
+}
+
+type config struct {
+	domain     string
+	policy     *stderr.Policy
+	observers  []Observer
+	panicHooks []PanicHook
+}
+
+func newConfig(options []Option) *config {
+	c := &config{domain: DefaultDomain}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+// ToStatus converts the error chain to a gRPC status. The gRPC code is taken from the definition of the first code
+// error in the global stderr.Catalog, or else mapped from the first status error using CodeFromHTTP; the first
+// message error is used as the status message; the first code error is used as the reason of an attached
+// errdetails.ErrorInfo, whose metadata contains values from all params errors in chain. Non-string param values
+// are JSON encoded.
+//
+// Errors not containing any of these errors are converted as is by status.FromError, so that gRPC statuses and
+// context errors retain their original code.
+func ToStatus(err error, options ...Option) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	c := newConfig(options)
+	view := stderr.ToView(err).Apply(c.policy)
+	metadata := collectParams(view)
+
+	if view.Status == 0 && len(view.Code) == 0 && len(view.Message) == 0 && len(metadata) == 0 {
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+		return status.FromContextError(err)
+	}
+
+	code := codes.Unknown
+	if d, ok := stderr.Lookup(view.Code); ok && d.GRPCCode > 0 {
+		code = codes.Code(d.GRPCCode)
+	} else if view.Status > 0 {
+		if code = CodeFromHTTP(view.Status); code == codes.OK {
+			code = codes.Unknown
+		}
+	} else if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		code = status.FromContextError(err).Code()
+	}
+
+	st := status.New(code, view.Message)
+
+	if len(view.Code) > 0 || len(metadata) > 0 {
+		info := &errdetails.ErrorInfo{
+			Reason:   view.Code,
+			Domain:   c.domain,
+			Metadata: metadata,
+		}
+		if withDetails, e := st.WithDetails(info); e == nil {
+			st = withDetails
+		}
+	}
+
+	return st
+}
+
+// FromStatus reconstructs the error chain from a gRPC status. The chain contains the status error converted using
+// HTTPFromCode, the code error and params error from the reason and metadata of the first errdetails.ErrorInfo, the
+// message error from the status message, and finally a generic cause carrying the status message, which also carries
+// the status itself, so that status.FromError and status.Code continue to work on the chain. It returns nil if the
+// status is nil or has codes.OK.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	chain := []error{stderr.Status(HTTPFromCode(st.Code()))}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+
+		if stderr.ValidCode(info.GetReason()) {
+			chain = append(chain, stderr.Code(info.GetReason()))
+		}
+
+		if len(info.GetMetadata()) > 0 {
+			var keys []string
+			for k := range info.GetMetadata() {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			var keysAndValues []interface{}
+			for _, k := range keys {
+				keysAndValues = append(keysAndValues, k, info.GetMetadata()[k])
+			}
+			chain = append(chain, stderr.Params(keysAndValues...))
+		}
+
+		break
+	}
+
+	if len(st.Message()) > 0 {
+		chain = append(chain, stderr.Message(st.Message()))
+	}
+
+	return stderr.Chain(append(chain, &statusCause{st: st})...)
+}
+
+// statusCause is the generic cause of chains reconstructed by FromStatus. Unlike the error returned by
+// status.Status.Err, its message is the bare status message, so that forwarding the chain does not pile up
+// "rpc error" prefixes on every hop.
+type statusCause struct {
+	st *status.Status
+}
+
+func (e *statusCause) Error() string {
+	if len(e.st.Message()) > 0 {
+		return e.st.Message()
+	}
+	return e.st.Code().String()
+}
+
+// GRPCStatus returns the status, for status.FromError and status.Code.
+func (e *statusCause) GRPCStatus() *status.Status {
+	return e.st
+}
+
+// FromError reconstructs the error chain from an error carrying a gRPC status using FromStatus. Errors not carrying
+// a gRPC status are returned as is.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return FromStatus(st)
+}
+
+func collectParams(view *stderr.View) map[string]string {
+	params := view.Params()
+	if len(params) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(params))
+	for k, v := range params {
+		if s, ok := v.(string); ok {
+			metadata[k] = s
+		} else if b, err := json.Marshal(v); err == nil {
+			metadata[k] = string(b)
+		}
+	}
+
+	return metadata
+}
@@ -0,0 +1,150 @@
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+// maxBodySize limits the number of bytes read from an error response.
+const maxBodySize = 1 << 20
+
+// DecodeResponse returns nil unless the response has a 4xx or 5xx status, so that redirects and 304 responses are
+// left to the caller. Otherwise, it consumes and closes the response body,
+// and reconstructs the error chain from the stderr.View carried in the body using stderr.FromView, or from the
+// stderr.Problem using stderr.FromProblem if the body has the application/problem+json media type. The status of the
+// response is added to the chain when the View does not provide one, and so is the Retry-After header as a retryable
+// typed error. Bodies that are not a View are converted to a chain of the response status and a generic error with
+// the body text.
+func DecodeResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return complete(resp, err)
+	}
+
+	decode := decodeView
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == stderr.ProblemContentType {
+		decode = decodeProblem
+	}
+
+	if err := decode(body); err != nil {
+		return complete(resp, err)
+	}
+
+	text := strings.TrimSpace(string(body))
+	if len(text) == 0 {
+		text = http.StatusText(resp.StatusCode)
+	}
+
+	return complete(resp, errors.New(text))
+}
+
+// complete adds the response status and the Retry-After header to the chain, unless the chain already suggests them.
+func complete(resp *http.Response, err error) error {
+	var retryable *stderr.RetryableError
+	if !errors.As(err, &retryable) {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			err = stderr.Chain(stderr.Retryable(stderr.WithRetryAfter(after)), err)
+		}
+	}
+
+	var status *stderr.StatusError
+	if !errors.As(err, &status) {
+		err = stderr.Chain(stderr.Status(resp.StatusCode), err)
+	}
+
+	return err
+}
+
+// retryAfter parses the Retry-After header value, which is either in seconds or a HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, seconds > 0
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		return d, d > 0
+	}
+
+	return 0, false
+}
+
+// decodeView returns the error chain reconstructed from body, or nil if body does not contain a View.
+func decodeView(body []byte) error {
+	view := new(stderr.View)
+	if err := json.Unmarshal(body, view); err != nil {
+		return nil
+	}
+
+	if view.Status == 0 && len(view.Code) == 0 && len(view.Message) == 0 && len(view.Context) == 0 {
+		return nil
+	}
+
+	if err := stderr.FromView(view); !errors.Is(err, stderr.ErrCorruptedView) {
+		return err
+	}
+
+	return nil
+}
+
+// decodeProblem returns the error chain reconstructed from body, or nil if body does not contain a Problem.
+func decodeProblem(body []byte) error {
+	problem := new(stderr.Problem)
+	if err := json.Unmarshal(body, problem); err != nil {
+		return nil
+	}
+
+	if err := stderr.FromProblem(problem, ""); !errors.Is(err, stderr.ErrCorruptedView) {
+		return err
+	}
+
+	return nil
+}
+
+// Transport is a http.RoundTripper that converts 4xx and 5xx responses into error chains using DecodeResponse. Other
+// responses, including redirects, are returned as is, so that http.Client keeps following redirects.
+//
+// Unlike the usual http.RoundTripper contract, Transport interprets the response: error responses are consumed and
+// returned as errors, so a http.Client using this Transport returns the reconstructed chain (wrapped in *url.Error)
+// instead of the response.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := DecodeResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
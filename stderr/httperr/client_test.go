@@ -0,0 +1,182 @@
+package httperr_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+)
+
+func TestTransport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/ok", httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}))
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.Handle("/view", httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return stderr.Chain(stderr.Status(404), stderr.Code("not_found"), stderr.Params("id", "42"))
+	}))
+	mux.Handle("/hidden_status", httperr.New(httperr.WithFields(httperr.FieldCode)).Handler(
+		func(w http.ResponseWriter, r *http.Request) error {
+			return stderr.Chain(stderr.Status(409), stderr.Code("conflict"))
+		},
+	))
+	mux.Handle("/problem", httperr.New(httperr.WithProblem("https://example.com/errors/")).Handler(
+		func(w http.ResponseWriter, r *http.Request) error {
+			return stderr.Chain(stderr.Status(403), stderr.Code("forbidden"), stderr.Message("access denied"))
+		},
+	))
+	mux.Handle("/retryable", httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return stderr.Chain(stderr.Status(503), stderr.Retryable(stderr.WithRetryAfter(1500*time.Millisecond)))
+	}))
+	mux.HandleFunc("/retry_after", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream is down", http.StatusBadGateway)
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"not a valid code!"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &http.Client{Transport: &httperr.Transport{}}
+
+	cases := []struct {
+		path string
+		run  func(t *testing.T, err error)
+	}{
+		{
+			path: "/ok",
+			run: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("expect no error, got %s", err)
+				}
+			},
+		},
+		{
+			path: "/old",
+			run: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("expect redirect to be followed, got %s", err)
+				}
+			},
+		},
+		{
+			path: "/view",
+			run: func(t *testing.T, err error) {
+				if !errors.Is(err, stderr.Status(404)) {
+					t.Error("expect status error in chain")
+				}
+				if !errors.Is(err, stderr.Code("not_found")) {
+					t.Error("expect code error in chain")
+				}
+
+				var params *stderr.ParamsError
+				if !errors.As(err, &params) {
+					t.Fatal("expect params error in chain")
+				}
+				if expect, actual := "42", params.Params()["id"]; expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+			},
+		},
+		{
+			path: "/hidden_status",
+			run: func(t *testing.T, err error) {
+				if !errors.Is(err, stderr.Status(409)) {
+					t.Error("expect status error in chain")
+				}
+				if !errors.Is(err, stderr.Code("conflict")) {
+					t.Error("expect code error in chain")
+				}
+			},
+		},
+		{
+			path: "/problem",
+			run: func(t *testing.T, err error) {
+				if !errors.Is(err, stderr.Status(403)) {
+					t.Error("expect status error in chain")
+				}
+				if !errors.Is(err, stderr.Code("forbidden")) {
+					t.Error("expect code error in chain")
+				}
+			},
+		},
+		{
+			path: "/retryable",
+			run: func(t *testing.T, err error) {
+				if !stderr.IsRetryable(err) {
+					t.Error("expect error to be retryable")
+				}
+				if expect, actual := 1500*time.Millisecond, retryAfter(err); expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+			},
+		},
+		{
+			path: "/retry_after",
+			run: func(t *testing.T, err error) {
+				if !errors.Is(err, stderr.Status(429)) {
+					t.Error("expect status error in chain")
+				}
+				if expect, actual := 30*time.Second, retryAfter(err); expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+			},
+		},
+		{
+			path: "/text",
+			run: func(t *testing.T, err error) {
+				if !errors.Is(err, stderr.Status(502)) {
+					t.Error("expect status error in chain")
+				}
+
+				var generic *stderr.GenericError
+				if !errors.As(err, &generic) {
+					t.Fatal("expect generic error in chain")
+				}
+				if expect, actual := "upstream is down", generic.Error(); expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+			},
+		},
+		{
+			path: "/json",
+			run: func(t *testing.T, err error) {
+				if !errors.Is(err, stderr.Status(400)) {
+					t.Error("expect status error in chain")
+				}
+				if errors.Is(err, stderr.ErrCorruptedView) {
+					t.Error("expect non-view body to fallback to generic error")
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			resp, err := client.Get(server.URL + c.path)
+			if err == nil {
+				resp.Body.Close()
+			}
+			c.run(t, err)
+		})
+	}
+}
+
+func retryAfter(err error) time.Duration {
+	d, _ := stderr.RetryAfter(err)
+	return d
+}
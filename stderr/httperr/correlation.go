@@ -0,0 +1,36 @@
+package httperr
+
+import (
+	"net/http"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+// RequestIDHeader is the header carrying the request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestCorrelation returns the stderr.Correlation of the request. The Correlation carried by the request context
+// takes precedence over the X-Request-ID and the W3C traceparent headers.
+func RequestCorrelation(r *http.Request) stderr.Correlation {
+	if r == nil {
+		return stderr.Correlation{}
+	}
+
+	if c := stderr.CorrelationFromContext(r.Context()); !c.IsZero() {
+		return c
+	}
+
+	c, _ := stderr.ParseTraceparent(r.Header.Get("traceparent"))
+	c.RequestID = r.Header.Get(RequestIDHeader)
+
+	return c
+}
+
+// WithRequestCorrelation provides an Option to place the correlation of the request, as determined by
+// RequestCorrelation, in front of the rendered chain, so that the response identifies the request that failed. It is
+// skipped if the first correlation in the chain is already the same.
+func WithRequestCorrelation() Option {
+	return func(r *Renderer) {
+		r.correlation = true
+	}
+}
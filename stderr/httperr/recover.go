@@ -0,0 +1,95 @@
+package httperr
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+// PanicHook is notified of every panic recovered by Recover, alongside the request, the recovered value and the
+// error chain converted from it by stderr.Recovered. It is meant for reporting, like sending the panic to an error
+// tracker.
+type PanicHook func(req *http.Request, recovered interface{}, err error)
+
+// WithPanicHook provides an Option to notify the PanicHook of every panic recovered by Recover. It may be supplied
+// multiple times, in which case the hooks are notified in order.
+func WithPanicHook(hook PanicHook) Option {
+	return func(r *Renderer) {
+		if hook != nil {
+			r.panicHooks = append(r.panicHooks, hook)
+		}
+	}
+}
+
+// Recover wraps the handler with a middleware that recovers panics, converts the recovered value into an error chain
+// with stderr.Recovered, notifies the hooks supplied with WithPanicHook, and renders the chain with this Renderer.
+//
+// As the chain carries the stack and the panic value, it is rendered with stderr.PublicPolicy unless the Renderer was
+// created with WithPolicy, for instance WithPolicy(stderr.InternalPolicy()) to expose the panic to trusted clients.
+// Nothing is rendered if the handler had written the response header before panicking, as the response can no longer
+// be replaced. http.ErrAbortHandler is panicked again, so that the server aborts the response as intended.
+func (r *Renderer) Recover(next http.Handler) http.Handler {
+	renderer := r
+	if r.policy == nil {
+		public := *r
+		public.policy = stderr.PublicPolicy()
+		renderer = &public
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rw := &recoverWriter{ResponseWriter: w}
+
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if e, ok := v.(error); ok && errors.Is(e, http.ErrAbortHandler) {
+				panic(v)
+			}
+
+			err := stderr.Recovered(v)
+			for _, hook := range r.panicHooks {
+				hook(req, v, err)
+			}
+			if !rw.written {
+				renderer.Render(w, req, err)
+			}
+		}()
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// Recover wraps the handler with a middleware that recovers panics and renders them with the Default Renderer.
+func Recover(next http.Handler) http.Handler {
+	return Default.Recover(next)
+}
+
+// recoverWriter records whether the response header has been written.
+type recoverWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *recoverWriter) WriteHeader(status int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recoverWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes the underlying http.ResponseWriter, if it supports flushing.
+func (w *recoverWriter) Flush() {
+	w.written = true
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for http.ResponseController to reach its optional interfaces.
+func (w *recoverWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
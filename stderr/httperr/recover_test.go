@@ -0,0 +1,120 @@
+package httperr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+)
+
+func TestRenderer_Recover(t *testing.T) {
+	var reported interface{}
+	renderer := httperr.New(httperr.WithPanicHook(func(_ *http.Request, recovered interface{}, err error) {
+		reported = recovered
+	}))
+
+	handler := renderer.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if expect, actual := 500, rec.Code; expect != actual {
+		t.Errorf("expect %d, actual %d", expect, actual)
+	}
+	if expect, actual := "boom", reported; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	view := new(stderr.View)
+	if err := json.NewDecoder(rec.Body).Decode(view); err != nil {
+		t.Fatal(err)
+	}
+	if expect, actual := stderr.CodeInternalError, view.Code; expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+}
+
+func TestRenderer_Recover_Policy(t *testing.T) {
+	cases := []struct {
+		name     string
+		recover  func(next http.Handler) http.Handler
+		internal bool
+	}{
+		{name: "default", recover: httperr.Recover},
+		{name: "without policy", recover: httperr.New().Recover},
+		{name: "internal", recover: httperr.New(httperr.WithPolicy(stderr.InternalPolicy())).Recover, internal: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			handler := c.recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic("db password=hunter2")
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			view := new(stderr.View)
+			if err := json.NewDecoder(rec.Body).Decode(view); err != nil {
+				t.Fatal(err)
+			}
+
+			var exposed bool
+			for _, n := range view.Context {
+				if n.Type == stderr.TypeStack || n.Type == stderr.TypeGeneric {
+					exposed = true
+				}
+			}
+			if expect, actual := c.internal, exposed; expect != actual {
+				t.Errorf("expect %t, actual %t", expect, actual)
+			}
+		})
+	}
+}
+
+func TestRenderer_Recover_Written(t *testing.T) {
+	var reported bool
+	renderer := httperr.New(httperr.WithPanicHook(func(*http.Request, interface{}, error) {
+		reported = true
+	}))
+
+	handler := renderer.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("partial"))
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if expect, actual := http.StatusAccepted, rec.Code; expect != actual {
+		t.Errorf("expect %d, actual %d", expect, actual)
+	}
+	if expect, actual := "partial", rec.Body.String(); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+	if !reported {
+		t.Error("expect panic to be reported")
+	}
+}
+
+func TestRenderer_Recover_Abort(t *testing.T) {
+	handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if v, ok := recover().(error); !ok || !errors.Is(v, http.ErrAbortHandler) {
+			t.Errorf("expect %v, actual %v", http.ErrAbortHandler, v)
+		}
+	}()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	t.Error("expect panic")
+}
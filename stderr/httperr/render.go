@@ -0,0 +1,283 @@
+// Package httperr renders stderr error chains as HTTP responses.
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/i18n"
+)
+
+// Field selects the top level fields of stderr.View exposed in the response body.
+type Field int
+
+const (
+	FieldStatus Field = 1 << iota
+	FieldCode
+	FieldMessage
+	FieldContext
+
+	// FieldAll exposes all fields of stderr.View.
+	FieldAll = FieldStatus | FieldCode | FieldMessage | FieldContext
+)
+
+// Default is the Renderer used by the package level Write function, HandlerFunc and Recover. It exposes all fields,
+// and only the context nodes allowed by stderr.PublicPolicy, as these responses usually go to untrusted clients.
+var Default = New(WithPolicy(stderr.PublicPolicy()))
+
+// New creates a Renderer. Without options, the Renderer exposes all View fields and context nodes, which is only
+// suitable for trusted clients. For untrusted clients, consider WithPolicy(stderr.PublicPolicy()). It uses
+// http.StatusInternalServerError when the error chain does not suggest a status.
+func New(options ...Option) *Renderer {
+	r := &Renderer{
+		fields:        FieldAll,
+		defaultStatus: http.StatusInternalServerError,
+	}
+
+	for _, opt := range options {
+		opt(r)
+	}
+
+	return r
+}
+
+// Option configures the Renderer.
+type Option func(r *Renderer)
+
+// WithFields provides an Option to select the View fields exposed in the response body. Status is always used as the
+// response status, even if it is not exposed in the body.
+func WithFields(fields Field) Option {
+	return func(r *Renderer) {
+		r.fields = fields
+	}
+}
+
+// WithNodeTypes provides an Option to expose only context nodes of the given types. It can be combined with
+// WithoutNodeTypes, in which case a node must be allowed by both.
+func WithNodeTypes(types ...string) Option {
+	return func(r *Renderer) {
+		if r.include == nil {
+			r.include = map[string]struct{}{}
+		}
+		for _, t := range types {
+			r.include[t] = struct{}{}
+		}
+	}
+}
+
+// WithoutNodeTypes provides an Option to hide context nodes of the given types, for example stderr.TypeGeneric.
+func WithoutNodeTypes(types ...string) Option {
+	return func(r *Renderer) {
+		if r.exclude == nil {
+			r.exclude = map[string]struct{}{}
+		}
+		for _, t := range types {
+			r.exclude[t] = struct{}{}
+		}
+	}
+}
+
+// WithDefaultStatus provides an Option to set the response status used when the error chain does not suggest a
+// valid one.
+func WithDefaultStatus(status int) Option {
+	return func(r *Renderer) {
+		if validStatus(status) {
+			r.defaultStatus = status
+		}
+	}
+}
+
+// WithPolicy provides an Option to apply the stderr.Policy to the context nodes, for instance stderr.PublicPolicy
+// for responses to untrusted clients. Node type options are applied after the policy.
+func WithPolicy(policy *stderr.Policy) Option {
+	return func(r *Renderer) {
+		r.policy = policy
+	}
+}
+
+// Localizer resolves the message of a View in the preferred languages. It is implemented by *i18n.Bundle.
+type Localizer interface {
+	Localize(v *stderr.View, langs ...string) *stderr.View
+}
+
+// WithLocalizer provides an Option to localize the View message in the languages preferred by the request, as
+// determined by i18n.RequestLocale. When rendering without a request, the fallback language of the Localizer is used.
+func WithLocalizer(localizer Localizer) Option {
+	return func(r *Renderer) {
+		r.localizer = localizer
+	}
+}
+
+// WithProblem provides an Option to render the View as a RFC 9457 problem details object with the
+// application/problem+json media type. The base is prefixed to the error code to form the problem type URI.
+func WithProblem(base string) Option {
+	return func(r *Renderer) {
+		r.problem = true
+		r.problemBase = base
+	}
+}
+
+// Observer is notified of every error rendered by Render, alongside the request, which may be nil, and the response
+// status. It is meant for instrumentation, like recording the error on a trace span or counting errors by code.
+type Observer func(req *http.Request, status int, err error)
+
+// WithObserver provides an Option to notify the Observer of every rendered error. It may be supplied multiple times,
+// in which case the observers are notified in order.
+func WithObserver(observer Observer) Option {
+	return func(r *Renderer) {
+		if observer != nil {
+			r.observers = append(r.observers, observer)
+		}
+	}
+}
+
+// Renderer converts error chains into JSON encoded stderr.View responses.
+type Renderer struct {
+	fields        Field
+	include       map[string]struct{}
+	exclude       map[string]struct{}
+	defaultStatus int
+	policy        *stderr.Policy
+	localizer     Localizer
+	problem       bool
+	problemBase   string
+	correlation   bool
+	observers     []Observer
+	panicHooks    []PanicHook
+}
+
+// View converts the error into a stderr.View, and returns the response status alongside the View with only the
+// exposed fields and context nodes.
+func (r *Renderer) View(err error) (int, *stderr.View) {
+	return r.view(nil, err)
+}
+
+func (r *Renderer) view(req *http.Request, err error) (int, *stderr.View) {
+	if r.correlation {
+		var existing *stderr.CorrelationError
+		if c := RequestCorrelation(req); !c.IsZero() && !(errors.As(err, &existing) && existing.Correlation() == c) {
+			err = stderr.Chain(stderr.Correlate(stderr.WithCorrelation(req.Context(), c)), err)
+		}
+	}
+
+	view := stderr.ToView(err)
+
+	if r.localizer != nil {
+		view = r.localizer.Localize(view, i18n.RequestLocale(req)...)
+	}
+
+	status := view.Status
+	if !validStatus(status) {
+		status = r.defaultStatus
+	}
+
+	if r.fields&FieldStatus == 0 {
+		view.Status = 0
+	}
+	if r.fields&FieldCode == 0 {
+		view.Code = ""
+	}
+	if r.fields&FieldMessage == 0 {
+		view.Message = ""
+	}
+	if r.fields&FieldContext == 0 {
+		view.Context = nil
+	} else {
+		view = view.Apply(r.policy)
+		view.Context = r.filter(view.Context)
+	}
+
+	return status, view
+}
+
+// Write renders the error to the response writer. Nothing is written when the error is nil.
+func (r *Renderer) Write(w http.ResponseWriter, err error) {
+	r.Render(w, nil, err)
+}
+
+// Render renders the error to the response writer in response to the request, which is used to decide the preferred
+// languages when a Localizer is configured. The request may be nil. Nothing is written when the error is nil. When
+// the chain suggests a retry-after duration, it is set as the Retry-After header in seconds. Observers are notified
+// before the response is written.
+func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, err error) {
+	if err == nil {
+		return
+	}
+
+	status, view := r.view(req, err)
+
+	for _, observe := range r.observers {
+		observe(req, status, err)
+	}
+
+	if after, ok := stderr.RetryAfter(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+	}
+
+	if r.problem {
+		w.Header().Set("Content-Type", stderr.ProblemContentType)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(view.Problem(r.problemBase))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(view)
+}
+
+// Handler adapts the HandlerFunc to a http.Handler which renders the returned error with this Renderer.
+func (r *Renderer) Handler(h HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.Render(w, req, h(w, req))
+	})
+}
+
+func (r *Renderer) filter(nodes []*stderr.Node) []*stderr.Node {
+	if len(r.include) == 0 && len(r.exclude) == 0 {
+		return nodes
+	}
+
+	results := make([]*stderr.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if _, ok := r.exclude[n.Type]; ok {
+			continue
+		}
+		if _, ok := r.include[n.Type]; len(r.include) > 0 && !ok {
+			continue
+		}
+		if len(n.Children) > 0 {
+			filtered := *n
+			filtered.Children = make([][]*stderr.Node, 0, len(n.Children))
+			for _, child := range n.Children {
+				filtered.Children = append(filtered.Children, r.filter(child))
+			}
+			n = &filtered
+		}
+		results = append(results, n)
+	}
+
+	return results
+}
+
+// Write renders the error to the response writer using the Default Renderer.
+func Write(w http.ResponseWriter, err error) {
+	Default.Write(w, err)
+}
+
+// HandlerFunc is a http handler that returns an error. The returned error, if any, is rendered as the response. Hence,
+// the handler should not write to the response writer when it returns a non-nil error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP implements http.Handler by rendering the returned error with the Default Renderer.
+func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	Default.Render(w, r, h(w, r))
+}
+
+func validStatus(status int) bool {
+	return status >= 100 && status <= 599
+}
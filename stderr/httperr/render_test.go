@@ -0,0 +1,236 @@
+package httperr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+)
+
+func TestRenderer_Handler(t *testing.T) {
+	chain := stderr.Chain(
+		stderr.Status(404),
+		stderr.Code("not_found"),
+		stderr.Message("item is not found"),
+		errors.New("sql: no rows in result set"),
+	)
+
+	cases := []struct {
+		name     string
+		renderer *httperr.Renderer
+		err      error
+		run      func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View)
+	}{
+		{
+			name:     "default",
+			renderer: httperr.New(),
+			err:      chain,
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				if expect, actual := 404, rec.Code; expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+				if expect, actual := "application/json; charset=utf-8", rec.Header().Get("Content-Type"); expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+				if expect, actual := "not_found", view.Code; expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+				if expect, actual := 4, len(view.Context); expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+			},
+		},
+		{
+			name:     "fallback status",
+			renderer: httperr.New(),
+			err:      errors.New("boom"),
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				if expect, actual := 500, rec.Code; expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+			},
+		},
+		{
+			name:     "selected fields",
+			renderer: httperr.New(httperr.WithFields(httperr.FieldCode | httperr.FieldMessage)),
+			err:      chain,
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				if expect, actual := 404, rec.Code; expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+				if view.Status != 0 {
+					t.Error("expect status to be hidden")
+				}
+				if len(view.Context) != 0 {
+					t.Error("expect context to be hidden")
+				}
+				if expect, actual := "item is not found", view.Message; expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+			},
+		},
+		{
+			name:     "hidden node types",
+			renderer: httperr.New(httperr.WithoutNodeTypes(stderr.TypeGeneric)),
+			err:      chain,
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				for _, n := range view.Context {
+					if n.Type == stderr.TypeGeneric {
+						t.Error("expect generic node to be hidden")
+					}
+				}
+			},
+		},
+		{
+			name:     "hidden branch",
+			renderer: httperr.New(httperr.WithoutNodeTypes(stderr.TypeGeneric)),
+			err:      stderr.Chain(stderr.Status(400), stderr.Join(errors.New("foo"), stderr.Chain(stderr.Code("bar"), errors.New("bar")))),
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				var aggregate *stderr.Node
+				for _, n := range view.Context {
+					if n.Type == stderr.TypeAggregate {
+						aggregate = n
+					}
+				}
+				if aggregate == nil || len(aggregate.Children) != 2 {
+					t.Fatal("expect both branches to be kept")
+				}
+				if expect, actual := 0, len(aggregate.Children[0]); expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+				if expect, actual := 1, len(aggregate.Children[1]); expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+			},
+		},
+		{
+			name:     "policy",
+			renderer: httperr.New(httperr.WithPolicy(stderr.PublicPolicy())),
+			err:      chain,
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				if expect, actual := 3, len(view.Context); expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+				for _, n := range view.Context {
+					if n.Type == stderr.TypeGeneric {
+						t.Error("expect generic node to be hidden")
+					}
+				}
+			},
+		},
+		{
+			name:     "retry after",
+			renderer: httperr.New(httperr.WithPolicy(stderr.PublicPolicy())),
+			err: stderr.Chain(
+				stderr.Status(503),
+				stderr.Retryable(stderr.WithRetryAfter(1500*time.Millisecond)),
+			),
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				if expect, actual := "2", rec.Header().Get("Retry-After"); expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+				if expect, actual := 2, len(view.Context); expect != actual {
+					t.Errorf("expect %d, actual %d", expect, actual)
+				}
+			},
+		},
+		{
+			name:     "request correlation",
+			renderer: httperr.New(httperr.WithRequestCorrelation()),
+			err:      chain,
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				if expect, actual := 5, len(view.Context); expect != actual {
+					t.Fatalf("expect %d, actual %d", expect, actual)
+				}
+
+				var correlation *stderr.CorrelationError
+				if !errors.As(stderr.FromView(view), &correlation) {
+					t.Fatal("expect correlation error in chain")
+				}
+				if expect, actual := (stderr.Correlation{
+					RequestID: "req-1",
+					TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+					SpanID:    "00f067aa0ba902b7",
+				}), correlation.Correlation(); expect != actual {
+					t.Errorf("expect %v, actual %v", expect, actual)
+				}
+			},
+		},
+		{
+			name:     "allowed node types",
+			renderer: httperr.New(httperr.WithNodeTypes(stderr.TypeCode)),
+			err:      chain,
+			run: func(t *testing.T, rec *httptest.ResponseRecorder, view *stderr.View) {
+				if expect, actual := 1, len(view.Context); expect != actual {
+					t.Fatalf("expect %d, actual %d", expect, actual)
+				}
+				if expect, actual := stderr.TypeCode, view.Context[0].Type; expect != actual {
+					t.Errorf("expect %s, actual %s", expect, actual)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := c.renderer.Handler(func(w http.ResponseWriter, r *http.Request) error {
+				return c.err
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			view := new(stderr.View)
+			if err := json.Unmarshal(rec.Body.Bytes(), view); err != nil {
+				t.Fatal(err)
+			}
+
+			c.run(t, rec, view)
+		})
+	}
+}
+
+func TestHandlerFunc_NoError(t *testing.T) {
+	h := httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if expect, actual := http.StatusNoContent, rec.Code; expect != actual {
+		t.Errorf("expect %d, actual %d", expect, actual)
+	}
+	if rec.Body.Len() != 0 {
+		t.Error("expect empty body")
+	}
+}
+
+func TestHandlerFunc_PublicPolicy(t *testing.T) {
+	h := httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return stderr.Chain(stderr.Status(500), stderr.Code("db_down"), errors.New("dial tcp db.internal:5432"))
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	view := new(stderr.View)
+	if err := json.NewDecoder(rec.Body).Decode(view); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range view.Context {
+		if n.Type == stderr.TypeGeneric {
+			t.Error("expect generic node to be hidden by default")
+		}
+	}
+}
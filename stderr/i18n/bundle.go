@@ -0,0 +1,182 @@
+// Package i18n resolves message keys in stderr error chains against localized message catalogs.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/absurdlab/pkg/stderr"
+	"gopkg.in/yaml.v3"
+)
+
+// New creates an empty Bundle with the fallback language, which is used when none of the requested languages has
+// the message.
+func New(fallback string) *Bundle {
+	return &Bundle{
+		fallback: normalizeTag(fallback),
+		messages: map[string]map[string]string{},
+	}
+}
+
+// Bundle holds message catalogs of multiple languages. A catalog maps message keys to message texts, which may
+// contain {name} or {name:format} placeholders to be interpolated with the params in the error chain, as described by
+// stderr.Interpolate.
+type Bundle struct {
+	lock     sync.RWMutex
+	fallback string
+	messages map[string]map[string]string
+}
+
+// Add adds the messages to the catalog of the language, replacing existing messages of the same key.
+func (b *Bundle) Add(lang string, messages map[string]string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	lang = normalizeTag(lang)
+	if b.messages[lang] == nil {
+		b.messages[lang] = map[string]string{}
+	}
+
+	for k, v := range messages {
+		b.messages[lang][k] = v
+	}
+}
+
+// LoadYAML adds the messages from YAML data to the catalog of the language. Nested mappings are flattened into dot
+// separated keys, so that item: {not_found: ...} defines the message of key item.not_found.
+func (b *Bundle) LoadYAML(lang string, data []byte) error {
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+
+	messages := map[string]string{}
+	if err := flatten("", tree, messages); err != nil {
+		return err
+	}
+
+	b.Add(lang, messages)
+
+	return nil
+}
+
+// LoadJSON adds the messages from JSON data to the catalog of the language. Nested objects are flattened in the
+// same way as LoadYAML.
+func (b *Bundle) LoadJSON(lang string, data []byte) error {
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+
+	messages := map[string]string{}
+	if err := flatten("", tree, messages); err != nil {
+		return err
+	}
+
+	b.Add(lang, messages)
+
+	return nil
+}
+
+// LoadFile adds the messages from a YAML or JSON file to the catalog of the language. The format is decided by the
+// file extension.
+func (b *Bundle) LoadFile(lang string, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return b.LoadYAML(lang, data)
+	case ".json":
+		return b.LoadJSON(lang, data)
+	default:
+		return fmt.Errorf("unsupported message file %s", path)
+	}
+}
+
+// Message returns the message of the key in the first language, in the order of preference, whose catalog defines
+// the key. A language with region, like zh-CN, also matches the catalog of its base language, like zh. The fallback
+// language is tried last.
+func (b *Bundle) Message(key string, langs ...string) (string, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	candidates := make([]string, 0, len(langs)+1)
+	candidates = append(candidates, langs...)
+	candidates = append(candidates, b.fallback)
+
+	for _, lang := range candidates {
+		lang = normalizeTag(lang)
+		if text, ok := b.messages[lang][key]; ok {
+			return text, true
+		}
+		if i := strings.IndexByte(lang, '-'); i > 0 {
+			if text, ok := b.messages[lang[:i]][key]; ok {
+				return text, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Localize returns a copy of the View whose message is resolved from the first message key in context, provided that
+// no message or template error precedes it in the chain. Placeholders in the message are interpolated with the params
+// in context using stderr.Interpolate. Keys not found in any catalog are used as the message as is. The View itself is not modified.
+func (b *Bundle) Localize(v *stderr.View, langs ...string) *stderr.View {
+	result := *v
+
+	for _, n := range v.Context {
+		if n.Type == stderr.TypeMessage || n.Type == stderr.TypeTemplate {
+			break
+		}
+		if n.Type != stderr.TypeMessageKey {
+			continue
+		}
+
+		var temp struct {
+			Key string `json:"key"`
+		}
+		if err := json.Unmarshal(n.Data, &temp); err != nil || len(temp.Key) == 0 {
+			continue
+		}
+
+		text, ok := b.Message(temp.Key, langs...)
+		if !ok {
+			text = temp.Key
+		}
+		result.Message = stderr.Interpolate(text, v.Params())
+
+		break
+	}
+
+	return &result
+}
+
+func flatten(prefix string, tree map[string]interface{}, into map[string]string) error {
+	for k, v := range tree {
+		key := k
+		if len(prefix) > 0 {
+			key = prefix + "." + k
+		}
+
+		switch value := v.(type) {
+		case string:
+			into[key] = value
+		case map[string]interface{}:
+			if err := flatten(key, value, into); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %s must be a string", key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package i18n_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+	"github.com/absurdlab/pkg/stderr/i18n"
+)
+
+func TestBundle_Localize(t *testing.T) {
+	bundle := newBundle(t)
+
+	err := stderr.Chain(
+		stderr.Status(404),
+		stderr.MessageKey("item.not_found"),
+		stderr.Params("id", "42"),
+	)
+
+	cases := []struct {
+		name   string
+		err    error
+		langs  []string
+		expect string
+	}{
+		{name: "english", err: err, langs: []string{"en"}, expect: "Item 42 is not found."},
+		{name: "chinese with region", err: err, langs: []string{"zh-CN", "en"}, expect: "找不到商品 42。"},
+		{name: "fallback", err: err, langs: []string{"fr"}, expect: "Item 42 is not found."},
+		{name: "unknown key", err: stderr.MessageKey("item.unknown"), expect: "item.unknown"},
+		{
+			name:   "preceding message",
+			err:    stderr.Chain(stderr.Message("literal"), err),
+			langs:  []string{"zh"},
+			expect: "literal",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			view := stderr.ToView(c.err)
+			if expect, actual := c.expect, bundle.Localize(view, c.langs...).Message; expect != actual {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+		})
+	}
+}
+
+func TestBundle_Localize_RoundTrip(t *testing.T) {
+	bundle := newBundle(t)
+
+	jsonBytes, err := json.Marshal(stderr.ToView(stderr.Chain(
+		stderr.Status(404),
+		stderr.MessageKey("item.not_found"),
+		stderr.Params("id", int64(1311768467463790321)),
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := new(stderr.View)
+	if err := json.Unmarshal(jsonBytes, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect, actual := "Item 1311768467463790321 is not found.", bundle.Localize(decoded, "en").Message; expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	actual := i18n.ParseAcceptLanguage("fr;q=0.5, zh-CN, en;q=0.8, *;q=0.1, de;q=0")
+	if expect := "zh-CN,en,fr"; expect != strings.Join(actual, ",") {
+		t.Errorf("expect %s, actual %v", expect, actual)
+	}
+}
+
+func TestRenderer_WithLocalizer(t *testing.T) {
+	renderer := httperr.New(httperr.WithLocalizer(newBundle(t)))
+	handler := renderer.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return stderr.Chain(stderr.Status(404), stderr.MessageKey("item.not_found"), stderr.Params("id", "42"))
+	})
+
+	cases := []struct {
+		name    string
+		request func() *http.Request
+		expect  string
+	}{
+		{
+			name: "accept language",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+				return req
+			},
+			expect: "找不到商品 42。",
+		},
+		{
+			name: "context",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+				return req.WithContext(i18n.WithLocale(context.Background(), "en"))
+			},
+			expect: "Item 42 is not found.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, c.request())
+
+			view := new(stderr.View)
+			if err := json.Unmarshal(rec.Body.Bytes(), view); err != nil {
+				t.Fatal(err)
+			}
+			if expect, actual := c.expect, view.Message; expect != actual {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+		})
+	}
+}
+
+func newBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+
+	bundle := i18n.New("en")
+	if err := bundle.LoadFile("en", "testdata/en.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.LoadFile("zh", "testdata/zh.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	return bundle
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type localeKey struct{}
+
+// WithLocale returns a copy of the context carrying the languages, in the order of preference.
+func WithLocale(ctx context.Context, langs ...string) context.Context {
+	return context.WithValue(ctx, localeKey{}, langs)
+}
+
+// Locale returns the languages carried by the context, or nil if there are none.
+func Locale(ctx context.Context) []string {
+	langs, _ := ctx.Value(localeKey{}).([]string)
+	return langs
+}
+
+// RequestLocale returns the languages preferred by the request. Languages carried by the request context take
+// precedence over those in the Accept-Language header.
+func RequestLocale(r *http.Request) []string {
+	if r == nil {
+		return nil
+	}
+
+	if langs := Locale(r.Context()); len(langs) > 0 {
+		return langs
+	}
+
+	return ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// ParseAcceptLanguage parses the value of an Accept-Language header, and returns the languages in the order of
+// preference. The wildcard and languages with zero quality are skipped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+
+	var entries []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+
+		lang := strings.TrimSpace(fields[0])
+		if len(lang) == 0 || lang == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+				quality = q
+			}
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		entries = append(entries, weighted{lang: lang, quality: quality})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].quality > entries[j].quality
+	})
+
+	langs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		langs = append(langs, e.lang)
+	}
+
+	return langs
+}
+
+func normalizeTag(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
@@ -0,0 +1,94 @@
+package stderr
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// LogValue implements slog.LogValuer. The View is logged as a group of status, code and message attributes, a params
+// group holding the params in context, request_id, trace_id and span_id attributes holding the correlation in
+// context, and a cause attribute holding the generic errors in context. Zero valued attributes are omitted.
+func (v *View) LogValue() slog.Value {
+	var attrs []slog.Attr
+
+	if v.Status > 0 {
+		attrs = append(attrs, slog.Int("status", v.Status))
+	}
+	if len(v.Code) > 0 {
+		attrs = append(attrs, slog.String("code", v.Code))
+	}
+	if len(v.Message) > 0 {
+		attrs = append(attrs, slog.String("message", v.Message))
+	}
+
+	var (
+		params      = v.Params()
+		correlation Correlation
+		causes      []string
+	)
+	for _, n := range v.Context {
+		switch n.Type {
+		case TypeCorrelation:
+			var temp Correlation
+			if json.Unmarshal(n.Data, &temp) != nil {
+				continue
+			}
+			if len(correlation.RequestID) == 0 {
+				correlation.RequestID = temp.RequestID
+			}
+			if len(correlation.TraceID) == 0 {
+				correlation.TraceID = temp.TraceID
+			}
+			if len(correlation.SpanID) == 0 {
+				correlation.SpanID = temp.SpanID
+			}
+		case TypeGeneric:
+			var temp genericErrorJSON
+			if json.Unmarshal(n.Data, &temp) == nil {
+				causes = append(causes, temp.Error)
+			}
+		case TypeAggregate:
+			var temp aggregateErrorJSON
+			if len(n.Data) > 0 && json.Unmarshal(n.Data, &temp) == nil && len(temp.Message) > 0 {
+				causes = append(causes, temp.Message)
+			}
+		}
+	}
+
+	if len(params) > 0 {
+		keys := make([]string, 0, len(params))
+		for k := range params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		paramAttrs := make([]slog.Attr, 0, len(keys))
+		for _, k := range keys {
+			paramAttrs = append(paramAttrs, slog.Any(k, params[k]))
+		}
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(paramAttrs...)})
+	}
+
+	if len(correlation.RequestID) > 0 {
+		attrs = append(attrs, slog.String("request_id", correlation.RequestID))
+	}
+	if len(correlation.TraceID) > 0 {
+		attrs = append(attrs, slog.String("trace_id", correlation.TraceID))
+	}
+	if len(correlation.SpanID) > 0 {
+		attrs = append(attrs, slog.String("span_id", correlation.SpanID))
+	}
+
+	if len(causes) > 0 {
+		attrs = append(attrs, slog.String("cause", strings.Join(causes, ": ")))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer by logging the View of the chain.
+func (l *link) LogValue() slog.Value {
+	return ToView(l).LogValue()
+}
@@ -0,0 +1,26 @@
+package stderr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestChain_LogValue(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	logger.Error("request failed", "err", stderr.Chain(stderr.Status(404), stderr.Code("not_found")))
+
+	var entry struct {
+		Err map[string]interface{} `json:"err"`
+	}
+	if e := json.Unmarshal(buf.Bytes(), &entry); e != nil {
+		t.Fatal(e)
+	}
+	if expect, actual := "not_found", entry.Err["code"]; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}
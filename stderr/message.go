@@ -14,7 +14,6 @@ func Message(message string) Error {
 
 type MessageError struct {
 	message string
-	next    Error
 }
 
 func (e *MessageError) Message() string {
@@ -25,26 +24,18 @@ func (e *MessageError) Is(_ error) bool {
 	return false
 }
 
-func (e *MessageError) wrap(err Error) {
-	e.next = err
-}
-
-func (e *MessageError) Unwrap() error {
-	return e.next
-}
-
 func (e *MessageError) Error() string {
 	return e.message
 }
 
-func (e *MessageError) asNode() (*node, error) {
+func (e *MessageError) MarshalNode() (*Node, error) {
 	jsonBytes, err := json.Marshal(messageErrorJSON{Message: e.message})
 	if err != nil {
 		return nil, err
 	}
 
-	return &node{
-		Type: typeMessage,
+	return &Node{
+		Type: TypeMessage,
 		Data: jsonBytes,
 	}, nil
 }
@@ -0,0 +1,52 @@
+package stderr
+
+import "encoding/json"
+
+// MessageKey returns a message key typed error. When placed in a chain of errors, this type of error suggests the
+// human-readable message to include in the API response by its key in a message catalog, so that the message can be
+// localized when the View is rendered. See the i18n package for resolving keys. The supplied key must not be empty.
+func MessageKey(key string) Error {
+	if len(key) == 0 {
+		panic("message key is required")
+	}
+	return &MessageKeyError{key: key}
+}
+
+type MessageKeyError struct {
+	key string
+}
+
+func (e *MessageKeyError) Key() string {
+	return e.key
+}
+
+func (e *MessageKeyError) Error() string {
+	return e.key
+}
+
+func (e *MessageKeyError) MarshalNode() (*Node, error) {
+	jsonBytes, err := json.Marshal(messageKeyErrorJSON{Key: e.key})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Type: TypeMessageKey,
+		Data: jsonBytes,
+	}, nil
+}
+
+func (e *MessageKeyError) UnmarshalJSON(bytes []byte) error {
+	var temp messageKeyErrorJSON
+	if err := json.Unmarshal(bytes, &temp); err != nil {
+		return err
+	}
+
+	e.key = temp.Key
+
+	return nil
+}
+
+type messageKeyErrorJSON struct {
+	Key string `json:"key"`
+}
@@ -0,0 +1,31 @@
+module github.com/absurdlab/pkg/stderr/otelerr
+
+go 1.23.0
+
+require (
+	github.com/absurdlab/pkg/stderr v0.0.0-00010101000000-000000000000
+	github.com/absurdlab/pkg/stderr/grpcerr v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.37.0
+	go.opentelemetry.io/otel/sdk v1.37.0
+	go.opentelemetry.io/otel/trace v1.37.0
+	google.golang.org/grpc v1.75.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.37.0 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/absurdlab/pkg/stderr => ../
+	github.com/absurdlab/pkg/stderr/grpcerr => ../grpcerr
+)
@@ -0,0 +1,214 @@
+// Package otelerr records stderr error chains on OpenTelemetry spans.
+package otelerr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/grpcerr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+)
+
+// Attribute keys set on the span. Exception event attributes follow the OpenTelemetry semantic conventions.
+const (
+	KeyErrorType           = attribute.Key("error.type")
+	KeyStatus              = attribute.Key("stderr.status")
+	KeyCode                = attribute.Key("stderr.code")
+	KeyMessage             = attribute.Key("stderr.message")
+	KeyParamsPrefix        = "stderr.params."
+	KeyExceptionType       = attribute.Key("exception.type")
+	KeyExceptionMessage    = attribute.Key("exception.message")
+	KeyExceptionStacktrace = attribute.Key("exception.stacktrace")
+)
+
+// Option configures the recording.
+type Option func(c *config)
+
+// WithPolicy provides an Option to apply the stderr.Policy to the chain before recording, for instance to hide the
+// generic causes when traces are exported to a third party.
+func WithPolicy(policy *stderr.Policy) Option {
+	return func(c *config) {
+		c.policy = policy
+	}
+}
+
+type config struct {
+	policy *stderr.Policy
+}
+
+func newConfig(options []Option) *config {
+	c := new(config)
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+// Record records the error chain on the span, and sets the span status to error with the message of the chain as
+// description. The status, code, message and params of the chain are set as span attributes, the code being the
+// error.type as well, and every context node, including those in the branches of aggregate nodes, is added as an
+// exception event whose type is the node type. Nothing is recorded if the error is nil or the span is not recording.
+func Record(span trace.Span, err error, options ...Option) {
+	record(span, err, true, newConfig(options))
+}
+
+// HTTPObserver returns a httperr.Observer that records rendered errors on the span of the request context. The span
+// status is set to error only for 5xx statuses, as 4xx statuses are the fault of the client.
+func HTTPObserver(options ...Option) httperr.Observer {
+	c := newConfig(options)
+	return func(req *http.Request, status int, err error) {
+		if req == nil {
+			return
+		}
+		record(trace.SpanFromContext(req.Context()), err, status >= 500, c)
+	}
+}
+
+// GRPCObserver returns a grpcerr.Observer that records errors returned by handlers on the span of the context. The
+// span status is set to error only for codes that are the fault of the server, as suggested by the OpenTelemetry
+// semantic conventions.
+func GRPCObserver(options ...Option) grpcerr.Observer {
+	c := newConfig(options)
+	return func(ctx context.Context, _ string, code grpccodes.Code, err error) {
+		record(trace.SpanFromContext(ctx), err, serverFault(code), c)
+	}
+}
+
+func record(span trace.Span, err error, setStatus bool, c *config) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	view := stderr.ToView(err).Apply(c.policy)
+
+	var attrs []attribute.KeyValue
+	if view.Status > 0 {
+		attrs = append(attrs, KeyStatus.Int(view.Status))
+	}
+	if len(view.Code) > 0 {
+		attrs = append(attrs, KeyCode.String(view.Code), KeyErrorType.String(view.Code))
+	}
+	if len(view.Message) > 0 {
+		attrs = append(attrs, KeyMessage.String(view.Message))
+	}
+	attrs = append(attrs, params(view)...)
+	span.SetAttributes(attrs...)
+
+	addEvents(span, view.Context)
+
+	if setStatus {
+		description := view.Message
+		if len(description) == 0 {
+			description = err.Error()
+		}
+		span.SetStatus(otelcodes.Error, description)
+	}
+}
+
+// params returns the params of the View as typed attributes.
+func params(view *stderr.View) []attribute.KeyValue {
+	merged := view.Params()
+
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, param(KeyParamsPrefix+k, merged[k]))
+	}
+
+	return attrs
+}
+
+// param returns the param value decoded by stderr.View.Params as a typed attribute. Integral numbers become integers,
+// so that attributes of IDs are searchable as such. Objects and arrays are JSON encoded.
+func param(key string, val interface{}) attribute.KeyValue {
+	switch v := val.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return attribute.Int64(key, int64(v))
+		}
+		return attribute.Float64(key, v)
+	default:
+		jsonBytes, err := json.Marshal(v)
+		if err != nil {
+			return attribute.String(key, fmt.Sprint(v))
+		}
+		return attribute.String(key, string(jsonBytes))
+	}
+}
+
+func addEvents(span trace.Span, nodes []*stderr.Node) {
+	for _, n := range nodes {
+		if n.Type == stderr.TypeAggregate {
+			for _, child := range n.Children {
+				addEvents(span, child)
+			}
+			continue
+		}
+
+		attrs := []attribute.KeyValue{KeyExceptionType.String(n.Type)}
+
+		switch n.Type {
+		case stderr.TypeGeneric:
+			var temp struct {
+				Error string `json:"error"`
+			}
+			if json.Unmarshal(n.Data, &temp) == nil {
+				attrs = append(attrs, KeyExceptionMessage.String(temp.Error))
+			}
+		case stderr.TypeStack:
+			var temp struct {
+				Frames []stderr.Frame `json:"frames"`
+			}
+			if json.Unmarshal(n.Data, &temp) == nil {
+				attrs = append(attrs, KeyExceptionStacktrace.String(stacktrace(temp.Frames)))
+			}
+		default:
+			attrs = append(attrs, KeyExceptionMessage.String(string(n.Data)))
+		}
+
+		span.AddEvent("exception", trace.WithAttributes(attrs...))
+	}
+}
+
+func stacktrace(frames []stderr.Frame) string {
+	var sb strings.Builder
+	for _, f := range frames {
+		_, _ = fmt.Fprintf(&sb, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+	}
+	return sb.String()
+}
+
+func serverFault(code grpccodes.Code) bool {
+	switch code {
+	case grpccodes.Unknown,
+		grpccodes.DeadlineExceeded,
+		grpccodes.Unimplemented,
+		grpccodes.Internal,
+		grpccodes.Unavailable,
+		grpccodes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,151 @@
+package otelerr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+	"github.com/absurdlab/pkg/stderr/otelerr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	grpccodes "google.golang.org/grpc/codes"
+)
+
+func TestRecord(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "op")
+	otelerr.Record(span, stderr.WithStack(stderr.Chain(
+		stderr.Status(404),
+		stderr.Code("not_found"),
+		stderr.Message("item is not found"),
+		stderr.Params("id", "42", "store", int64(1311768467463790321), "ratio", 0.5, "draft", true),
+		errors.New("sql: no rows in result set"),
+	)))
+	span.End()
+
+	ended := recorder.Ended()[0]
+
+	if expect, actual := codes.Error, ended.Status().Code; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := "item is not found", ended.Status().Description; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	attrs := attribute.NewSet(ended.Attributes()...)
+	for _, c := range []struct {
+		key    attribute.Key
+		expect attribute.Value
+	}{
+		{key: otelerr.KeyStatus, expect: attribute.IntValue(404)},
+		{key: otelerr.KeyCode, expect: attribute.StringValue("not_found")},
+		{key: otelerr.KeyErrorType, expect: attribute.StringValue("not_found")},
+		{key: otelerr.KeyMessage, expect: attribute.StringValue("item is not found")},
+		{key: otelerr.KeyParamsPrefix + "id", expect: attribute.StringValue("42")},
+		{key: otelerr.KeyParamsPrefix + "store", expect: attribute.Int64Value(1311768467463790321)},
+		{key: otelerr.KeyParamsPrefix + "ratio", expect: attribute.Float64Value(0.5)},
+		{key: otelerr.KeyParamsPrefix + "draft", expect: attribute.BoolValue(true)},
+	} {
+		if actual, ok := attrs.Value(c.key); !ok || actual != c.expect {
+			t.Errorf("expect %s to be %v, actual %v", c.key, c.expect.Emit(), actual.Emit())
+		}
+	}
+
+	var types []string
+	for _, event := range ended.Events() {
+		if expect, actual := "exception", event.Name; expect != actual {
+			t.Errorf("expect %v, actual %v", expect, actual)
+		}
+		set := attribute.NewSet(event.Attributes...)
+		value, _ := set.Value(otelerr.KeyExceptionType)
+		types = append(types, value.AsString())
+	}
+	if expect, actual := 6, len(types); expect != actual {
+		t.Fatalf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := stderr.TypeStack, types[5]; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	set := attribute.NewSet(ended.Events()[5].Attributes...)
+	stacktrace, _ := set.Value(otelerr.KeyExceptionStacktrace)
+	if len(stacktrace.AsString()) == 0 {
+		t.Error("expect stack trace to be recorded")
+	}
+}
+
+func TestObserver(t *testing.T) {
+	cases := []struct {
+		name    string
+		observe func(ctx context.Context, err error)
+		err     error
+		expect  codes.Code
+	}{
+		{
+			name: "http client fault",
+			observe: func(ctx context.Context, err error) {
+				observeHTTP(ctx, err)
+			},
+			err:    stderr.Chain(stderr.Status(404), stderr.Code("not_found")),
+			expect: codes.Unset,
+		},
+		{
+			name: "http server fault",
+			observe: func(ctx context.Context, err error) {
+				observeHTTP(ctx, err)
+			},
+			err:    stderr.Chain(stderr.Status(503), stderr.Code("unavailable")),
+			expect: codes.Error,
+		},
+		{
+			name: "grpc client fault",
+			observe: func(ctx context.Context, err error) {
+				otelerr.GRPCObserver()(ctx, "/svc/Method", grpccodes.NotFound, err)
+			},
+			err:    stderr.Chain(stderr.Status(404), stderr.Code("not_found")),
+			expect: codes.Unset,
+		},
+		{
+			name: "grpc server fault",
+			observe: func(ctx context.Context, err error) {
+				otelerr.GRPCObserver()(ctx, "/svc/Method", grpccodes.Internal, err)
+			},
+			err:    stderr.Chain(stderr.Status(500), stderr.Code("internal_error")),
+			expect: codes.Error,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+			ctx, span := tracer.Start(context.Background(), "op")
+			c.observe(ctx, c.err)
+			span.End()
+
+			ended := recorder.Ended()[0]
+			if expect, actual := c.expect, ended.Status().Code; expect != actual {
+				t.Errorf("expect %v, actual %v", expect, actual)
+			}
+			if expect, actual := 2, len(ended.Events()); expect != actual {
+				t.Errorf("expect %v, actual %v", expect, actual)
+			}
+		})
+	}
+}
+
+func observeHTTP(ctx context.Context, err error) {
+	renderer := httperr.New(httperr.WithObserver(otelerr.HTTPObserver()))
+	h := renderer.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return err
+	})
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+}
@@ -0,0 +1,27 @@
+package stderr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CodeInternalError is the code of the errors converted from recovered panics by Recovered.
+const CodeInternalError = "internal_error"
+
+// Recovered converts the value recovered from a panic into a chain of Status(500), Code(CodeInternalError), a stack
+// typed error recording the frames of the caller, and the panic value as the generic cause. It is meant to be called
+// from the deferred function calling recover, so that the recorded frames include the panicking call. The cause is
+// prefixed with "panic: ", wrapping error values so that errors.Is and errors.As still match them, and formatting
+// other values. It returns nil if the value is nil.
+func Recovered(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+
+	cause, ok := v.(error)
+	if !ok {
+		cause = errors.New(fmt.Sprint(v))
+	}
+
+	return Chain(Status(500), Code(CodeInternalError), callers(3), fmt.Errorf("panic: %w", cause))
+}
@@ -0,0 +1,64 @@
+package stderr_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestRecovered(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   interface{}
+		message string
+	}{
+		{name: "string", value: "boom", message: "panic: boom"},
+		{name: "error", value: io.EOF, message: "panic: EOF"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := recovered(c.value)
+
+			view := stderr.ToView(err)
+			if expect, actual := 500, view.Status; expect != actual {
+				t.Errorf("expect %d, actual %d", expect, actual)
+			}
+			if expect, actual := stderr.CodeInternalError, view.Code; expect != actual {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+			var cause *stderr.GenericError
+			if !errors.As(err, &cause) {
+				t.Fatal("expect generic error in chain")
+			}
+			if expect, actual := c.message, cause.Error(); expect != actual {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+
+			var stack *stderr.StackError
+			if !errors.As(err, &stack) {
+				t.Fatal("expect stack in chain")
+			}
+			if expect, actual := "stderr_test.recovered.func1", stack.Frames()[0].Function; !strings.HasSuffix(actual, expect) {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+		})
+	}
+
+	if !errors.Is(recovered(io.EOF), io.EOF) {
+		t.Error("expect recovered error to match panic value")
+	}
+	if stderr.Recovered(nil) != nil {
+		t.Error("expect nil")
+	}
+}
+
+func recovered(v interface{}) (err error) {
+	defer func() {
+		err = stderr.Recovered(recover())
+	}()
+	panic(v)
+}
@@ -1,6 +1,7 @@
 package stderr
 
 import (
+	"bytes"
 	"encoding/json"
 	"strings"
 )
@@ -27,7 +28,6 @@ func Params(keysAndValues ...interface{}) Error {
 
 type ParamsError struct {
 	params map[string]interface{}
-	next   Error
 }
 
 func (e *ParamsError) Params() map[string]interface{} {
@@ -51,29 +51,21 @@ func (e *ParamsError) Error() string {
 	return "params: " + strings.Join(keys, ", ")
 }
 
-func (e *ParamsError) Unwrap() error {
-	return e.next
-}
-
-func (e *ParamsError) wrap(err Error) {
-	e.next = err
-}
-
-func (e *ParamsError) asNode() (*node, error) {
+func (e *ParamsError) MarshalNode() (*Node, error) {
 	jsonBytes, err := json.Marshal(e.params)
 	if err != nil {
 		return nil, err
 	}
 
-	return &node{
-		Type: typeParams,
+	return &Node{
+		Type: TypeParams,
 		Data: jsonBytes,
 	}, nil
 }
 
 func (e *ParamsError) UnmarshalJSON(bytes []byte) error {
-	var temp = make(map[string]interface{})
-	if err := json.Unmarshal(bytes, &temp); err != nil {
+	temp, err := decodeParams(bytes)
+	if err != nil {
 		return err
 	}
 
@@ -81,3 +73,66 @@ func (e *ParamsError) UnmarshalJSON(bytes []byte) error {
 
 	return nil
 }
+
+// Params merges the params in context. Outer params take precedence over inner ones of the same name. Integral
+// numbers are decoded as int64, so that IDs beyond the precision of float64 stay intact, and other numbers as float64.
+func (v *View) Params() map[string]interface{} {
+	params := map[string]interface{}{}
+
+	for _, n := range v.Context {
+		if n.Type != TypeParams {
+			continue
+		}
+
+		temp, err := decodeParams(n.Data)
+		if err != nil {
+			continue
+		}
+
+		for k, val := range temp {
+			if _, ok := params[k]; !ok {
+				params[k] = val
+			}
+		}
+	}
+
+	return params
+}
+
+// decodeParams decodes JSON encoded params, with integral numbers as int64 and other numbers as float64.
+func decodeParams(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	temp := make(map[string]interface{})
+	if err := decoder.Decode(&temp); err != nil {
+		return nil, err
+	}
+
+	for k, val := range temp {
+		temp[k] = number(val)
+	}
+
+	return temp, nil
+}
+
+// number converts json.Number values, including those nested in objects and arrays, to int64 or float64.
+func number(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, each := range v {
+			v[k] = number(each)
+		}
+	case []interface{}:
+		for i, each := range v {
+			v[i] = number(each)
+		}
+	}
+	return val
+}
@@ -0,0 +1,147 @@
+package stderr
+
+import "encoding/json"
+
+// InternalPolicy returns a Policy exposing all context nodes. It is meant for traffic between trusted services, which
+// benefit from the full error chain.
+func InternalPolicy() *Policy {
+	return &Policy{}
+}
+
+// PublicPolicy returns a Policy exposing only status, code, message, template, message key, params, violations,
+// retryable, correlation and aggregate context nodes. Generic typed nodes, which often carry details like queries,
+// file paths or hostnames, stack traces and custom typed nodes are hidden, including those within the branches of
+// aggregate typed nodes. The messages of aggregate typed nodes are redacted for the same reason, and so are the
+// rejected values of violations typed nodes, as they may hold passwords, tokens or personal data. It is meant for
+// responses to untrusted clients. Every call returns a new Policy, which the caller may extend without affecting
+// others.
+func PublicPolicy() *Policy {
+	return &Policy{
+		Types: []string{
+			TypeStatus,
+			TypeCode,
+			TypeMessage,
+			TypeTemplate,
+			TypeMessageKey,
+			TypeParams,
+			TypeViolations,
+			TypeRetryable,
+			TypeCorrelation,
+			TypeAggregate,
+		},
+		Redactors: map[string]Redactor{
+			TypeViolations: RedactViolationValues(),
+			TypeAggregate:  redactAggregateMessage,
+		},
+	}
+}
+
+// Policy decides which context nodes of a View are exposed to an audience.
+type Policy struct {
+	// Types is the allowlist of context node types. When empty, nodes of all types are allowed.
+	Types []string
+	// Redactors maps context node types to a Redactor, which is applied to each allowed node of the type.
+	Redactors map[string]Redactor
+}
+
+// Redactor rewrites a context node before it is exposed. It must not modify the supplied node, but return a new one
+// instead. Returning nil hides the node entirely.
+type Redactor func(n *Node) *Node
+
+// RedactGeneric returns a Redactor that replaces the message of generic typed nodes with the text.
+func RedactGeneric(text string) Redactor {
+	return func(n *Node) *Node {
+		jsonBytes, err := json.Marshal(genericErrorJSON{Error: text})
+		if err != nil {
+			return nil
+		}
+		return &Node{Type: n.Type, Data: jsonBytes}
+	}
+}
+
+// RedactViolationValues returns a Redactor that removes the rejected values from violations typed nodes, keeping the
+// fields, rules and messages.
+func RedactViolationValues() Redactor {
+	return func(n *Node) *Node {
+		var temp violationsErrorJSON
+		if err := json.Unmarshal(n.Data, &temp); err != nil {
+			return nil
+		}
+
+		violations := make([]Violation, 0, len(temp.Violations))
+		for _, v := range temp.Violations {
+			v.Value = nil
+			violations = append(violations, v)
+		}
+
+		jsonBytes, err := json.Marshal(violationsErrorJSON{Violations: violations})
+		if err != nil {
+			return nil
+		}
+		return &Node{Type: n.Type, Data: jsonBytes}
+	}
+}
+
+// redactAggregateMessage removes the message of aggregate typed nodes, which often repeats the generic causes of the
+// branches, and keeps the children.
+func redactAggregateMessage(n *Node) *Node {
+	redacted := *n
+	redacted.Data = nil
+	return &redacted
+}
+
+// Apply returns a copy of the View with only the context nodes allowed by the Policy, after redaction. The children
+// of aggregate typed nodes are subject to the Policy as well. The View itself is not modified. A nil Policy allows
+// everything.
+func (v *View) Apply(p *Policy) *View {
+	result := *v
+	if p == nil {
+		return &result
+	}
+
+	result.Context = p.apply(v.Context)
+
+	return &result
+}
+
+func (p *Policy) apply(nodes []*Node) []*Node {
+	results := make([]*Node, 0, len(nodes))
+	for _, n := range nodes {
+		if !p.allows(n.Type) {
+			continue
+		}
+
+		if redact, ok := p.Redactors[n.Type]; ok && redact != nil {
+			if n = redact(n); n == nil {
+				continue
+			}
+		}
+
+		if len(n.Children) > 0 {
+			applied := *n
+			applied.Children = make([][]*Node, 0, len(n.Children))
+			for _, child := range n.Children {
+				applied.Children = append(applied.Children, p.apply(child))
+			}
+			n = &applied
+		}
+
+		results = append(results, n)
+	}
+
+	return results
+}
+
+func (p *Policy) allows(nodeType string) bool {
+	if len(p.Types) == 0 {
+		return true
+	}
+
+	for _, t := range p.Types {
+		if t == nodeType {
+			return true
+		}
+	}
+
+	return false
+}
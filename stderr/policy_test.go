@@ -0,0 +1,95 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestView_Apply(t *testing.T) {
+	view := stderr.ToView(stderr.Chain(
+		stderr.Status(500),
+		stderr.Code("internal_error"),
+		stderr.Stack(),
+		errors.New("dial tcp db.internal:5432: connection refused"),
+	))
+
+	cases := []struct {
+		name   string
+		policy *stderr.Policy
+		expect []string
+	}{
+		{
+			name:   "internal",
+			policy: stderr.InternalPolicy(),
+			expect: []string{stderr.TypeStatus, stderr.TypeCode, stderr.TypeStack, stderr.TypeGeneric},
+		},
+		{
+			name:   "public",
+			policy: stderr.PublicPolicy(),
+			expect: []string{stderr.TypeStatus, stderr.TypeCode},
+		},
+		{
+			name: "redactor",
+			policy: &stderr.Policy{
+				Redactors: map[string]stderr.Redactor{
+					stderr.TypeStack:   func(n *stderr.Node) *stderr.Node { return nil },
+					stderr.TypeGeneric: stderr.RedactGeneric("internal error"),
+				},
+			},
+			expect: []string{stderr.TypeStatus, stderr.TypeCode, stderr.TypeGeneric},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			applied := view.Apply(c.policy)
+
+			var actual []string
+			for _, n := range applied.Context {
+				actual = append(actual, n.Type)
+				if strings.Contains(string(n.Data), "db.internal") && c.name != "internal" {
+					t.Error("expect generic error text to be hidden")
+				}
+			}
+
+			if strings.Join(c.expect, ",") != strings.Join(actual, ",") {
+				t.Errorf("expect %v, actual %v", c.expect, actual)
+			}
+			if expect, actual := 4, len(view.Context); expect != actual {
+				t.Errorf("expect original view to be intact, got %d nodes", actual)
+			}
+		})
+	}
+}
+
+func TestView_Apply_EmptyBranch(t *testing.T) {
+	view := stderr.ToView(stderr.Join(
+		stderr.Chain(stderr.Stack(), errors.New("foo")),
+		stderr.Chain(stderr.Code("bar"), errors.New("bar")),
+	))
+
+	applied := view.Apply(&stderr.Policy{Types: []string{stderr.TypeAggregate, stderr.TypeCode}})
+
+	jsonBytes, err := json.Marshal(applied.Context)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expect, actual := `[{"type":"aggregate","children":[[],[{"type":"code","data":{"code":"bar"}}]]}]`, string(jsonBytes); expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+}
+
+func TestPublicPolicy_Copy(t *testing.T) {
+	widened := stderr.PublicPolicy()
+	widened.Types = append(widened.Types, stderr.TypeGeneric)
+
+	view := stderr.ToView(stderr.Chain(stderr.Status(500), errors.New("dial tcp db.internal:5432")))
+	if expect, actual := 1, len(view.Apply(stderr.PublicPolicy()).Context); expect != actual {
+		t.Errorf("expect %d, actual %d", expect, actual)
+	}
+}
@@ -0,0 +1,239 @@
+package stderr
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// ProblemContentType is the media type of a JSON encoded Problem.
+const ProblemContentType = "application/problem+json"
+
+const problemBlankType = "about:blank"
+
+// Problem is the RFC 9457 (formerly RFC 7807) Problem Details representation of a View. Members not defined by the
+// specification are kept in Extensions, except for context, which carries View.Context so that the full error chain
+// can be restored by the receiver.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Context    []*Node
+	Extensions map[string]interface{}
+}
+
+// ToProblem converts the error into a Problem using ToView and View.Problem.
+func ToProblem(err error, base string) *Problem {
+	return ToView(err).Problem(base)
+}
+
+// Problem converts the View to a Problem. The type member is the View.Code prefixed with base, for instance base
+// "https://example.com/errors/" and code "not_found" gives "https://example.com/errors/not_found", or "about:blank"
+// when the View has no code. The title member is View.Message, and the detail member is the next message in context,
+// if any. Params errors in context become extension members, the ones closer to the head of the chain take precedence.
+func (v *View) Problem(base string) *Problem {
+	p := &Problem{
+		Type:    problemBlankType,
+		Title:   v.Message,
+		Status:  v.Status,
+		Context: v.Context,
+	}
+
+	if len(v.Code) > 0 {
+		p.Type = base + v.Code
+	}
+
+	var titleSeen bool
+	for _, n := range v.Context {
+		switch n.Type {
+		case TypeMessage:
+			var m messageErrorJSON
+			if json.Unmarshal(n.Data, &m) != nil || len(p.Detail) > 0 {
+				continue
+			}
+			if !titleSeen && m.Message == p.Title {
+				titleSeen = true
+				continue
+			}
+			p.Detail = m.Message
+		}
+	}
+
+	for k, val := range v.Params() {
+		if _, reserved := problemMembers[k]; reserved {
+			continue
+		}
+		if p.Extensions == nil {
+			p.Extensions = map[string]interface{}{}
+		}
+		p.Extensions[k] = val
+	}
+
+	return p
+}
+
+// View converts the Problem back to a View. The code is recovered by removing base from the type member. When base
+// is empty, the last segment of the type member is used instead. When the Problem does not carry context, it is
+// reconstructed from the status, code, title, detail and extension members.
+func (p *Problem) View(base string) *View {
+	v := &View{
+		Status:  p.Status,
+		Message: p.Title,
+		Context: p.Context,
+	}
+
+	if len(v.Message) == 0 {
+		v.Message = p.Detail
+	}
+
+	if code := p.code(base); ValidCode(code) {
+		v.Code = code
+	}
+
+	if len(v.Context) == 0 {
+		var chain []error
+		{
+			if v.Status > 0 {
+				chain = append(chain, Status(v.Status))
+			}
+			if len(v.Code) > 0 {
+				chain = append(chain, Code(v.Code))
+			}
+			if len(p.Title) > 0 {
+				chain = append(chain, Message(p.Title))
+			}
+			if len(p.Detail) > 0 {
+				chain = append(chain, Message(p.Detail))
+			}
+			if len(p.Extensions) > 0 {
+				var keys []string
+				for k := range p.Extensions {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+
+				var keysAndValues []interface{}
+				for _, k := range keys {
+					keysAndValues = append(keysAndValues, k, p.Extensions[k])
+				}
+				chain = append(chain, Params(keysAndValues...))
+			}
+		}
+
+		if len(chain) > 0 {
+			if nodes, err := collectNodes(Chain(chain...)); err == nil {
+				v.Context = nodes
+			}
+		}
+	}
+
+	return v
+}
+
+func (p *Problem) code(base string) string {
+	if len(p.Type) == 0 || p.Type == problemBlankType {
+		return ""
+	}
+
+	if len(base) > 0 {
+		if !strings.HasPrefix(p.Type, base) {
+			return ""
+		}
+		return strings.TrimPrefix(p.Type, base)
+	}
+
+	if i := strings.LastIndexAny(p.Type, "/#:"); i >= 0 {
+		return p.Type[i+1:]
+	}
+
+	return p.Type
+}
+
+// FromProblem restores the error chain from the Problem using Problem.View and FromView.
+func FromProblem(p *Problem, base string) error {
+	return FromView(p.View(base))
+}
+
+// MarshalJSON encodes the Problem as a JSON object, with extension members alongside the standard members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+6)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+
+	if len(p.Type) > 0 {
+		members["type"] = p.Type
+	}
+	if len(p.Title) > 0 {
+		members["title"] = p.Title
+	}
+	if p.Status > 0 {
+		members["status"] = p.Status
+	}
+	if len(p.Detail) > 0 {
+		members["detail"] = p.Detail
+	}
+	if len(p.Instance) > 0 {
+		members["instance"] = p.Instance
+	}
+	if len(p.Context) > 0 {
+		members["context"] = p.Context
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON decodes the Problem from a JSON object. Members other than the standard ones and context are
+// decoded into Extensions.
+func (p *Problem) UnmarshalJSON(bytes []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &members); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+
+	for k, raw := range members {
+		var err error
+
+		switch k {
+		case "type":
+			err = json.Unmarshal(raw, &p.Type)
+		case "title":
+			err = json.Unmarshal(raw, &p.Title)
+		case "status":
+			err = json.Unmarshal(raw, &p.Status)
+		case "detail":
+			err = json.Unmarshal(raw, &p.Detail)
+		case "instance":
+			err = json.Unmarshal(raw, &p.Instance)
+		case "context":
+			err = json.Unmarshal(raw, &p.Context)
+		default:
+			var v interface{}
+			if err = json.Unmarshal(raw, &v); err == nil {
+				if p.Extensions == nil {
+					p.Extensions = map[string]interface{}{}
+				}
+				p.Extensions[k] = v
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var problemMembers = map[string]struct{}{
+	"type":     {},
+	"title":    {},
+	"status":   {},
+	"detail":   {},
+	"instance": {},
+	"context":  {},
+}
@@ -0,0 +1,97 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestProblem(t *testing.T) {
+	const base = "https://example.com/errors/"
+
+	err := stderr.Chain(
+		stderr.Status(404),
+		stderr.Code("not_found"),
+		stderr.Message("item is not found"),
+		stderr.Params("id", "42"),
+		stderr.Message("no such item in store"),
+		errors.New("sql: no rows in result set"),
+	)
+
+	problem := stderr.ToProblem(err, base)
+	{
+		if expect, actual := base+"not_found", problem.Type; expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+		if expect, actual := "item is not found", problem.Title; expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+		if expect, actual := "no such item in store", problem.Detail; expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+		if expect, actual := "42", problem.Extensions["id"]; expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+	}
+
+	jsonBytes, e := json.Marshal(problem)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	var members map[string]interface{}
+	if e := json.Unmarshal(jsonBytes, &members); e != nil {
+		t.Fatal(e)
+	}
+	if expect, actual := "42", members["id"]; expect != actual {
+		t.Errorf("expect extension member to be inlined, got %v", actual)
+	}
+
+	cases := []struct {
+		name   string
+		modify func(p *stderr.Problem)
+	}{
+		{name: "with context", modify: func(p *stderr.Problem) {}},
+		{name: "without context", modify: func(p *stderr.Problem) { p.Context = nil }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			decoded := new(stderr.Problem)
+			if e := json.Unmarshal(jsonBytes, decoded); e != nil {
+				t.Fatal(e)
+			}
+			c.modify(decoded)
+
+			err2 := stderr.FromProblem(decoded, base)
+			if !errors.Is(err2, stderr.Status(404)) {
+				t.Error("expect recovered error to have status error in chain")
+			}
+			if !errors.Is(err2, stderr.Code("not_found")) {
+				t.Error("expect recovered error to have code error in chain")
+			}
+
+			var params *stderr.ParamsError
+			if !errors.As(err2, &params) {
+				t.Fatal("expect recovered error to have params error in chain")
+			}
+			if expect, actual := "42", params.Params()["id"]; expect != actual {
+				t.Errorf("expect %s, actual %s", expect, actual)
+			}
+		})
+	}
+}
+
+func TestProblem_BlankType(t *testing.T) {
+	problem := stderr.ToProblem(stderr.Chain(stderr.Status(500), stderr.Message("oops")), "")
+	if expect, actual := "about:blank", problem.Type; expect != actual {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+
+	view := problem.View("")
+	if len(view.Code) != 0 {
+		t.Errorf("expect no code, got %s", view.Code)
+	}
+}
@@ -0,0 +1,119 @@
+// Package promerr counts rendered stderr error chains with Prometheus, by error code and status.
+package promerr
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OtherCode is the code label of errors whose code is not allowed, which guards the cardinality of the counter.
+const OtherCode = "other"
+
+// Option configures the Collector.
+type Option func(c *Collector)
+
+// WithNamespace provides an Option to set the namespace of the metric. The default namespace is stderr, which makes
+// the metric stderr_http_errors_total.
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithCodes provides an Option to allow the codes as label values. It may be supplied multiple times.
+func WithCodes(codes ...string) Option {
+	return func(c *Collector) {
+		if c.codes == nil {
+			c.codes = map[string]struct{}{}
+		}
+		for _, code := range codes {
+			c.codes[code] = struct{}{}
+		}
+	}
+}
+
+// WithCatalog provides an Option to allow the codes declared in the stderr.Catalog as label values.
+func WithCatalog(catalog *stderr.Catalog) Option {
+	return func(c *Collector) {
+		c.catalog = catalog
+	}
+}
+
+// New creates a Collector. Codes are allowed as label values if supplied with WithCodes, or declared in the Catalog
+// supplied with WithCatalog. Without either option, codes declared in the global stderr.Catalog are allowed. Other
+// codes are counted as OtherCode.
+func New(options ...Option) *Collector {
+	c := &Collector{namespace: "stderr"}
+	for _, opt := range options {
+		opt(c)
+	}
+
+	c.counter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: c.namespace,
+		Subsystem: "http",
+		Name:      "errors_total",
+		Help:      "Number of errors rendered, by error code and response status.",
+	}, []string{"code", "status"})
+
+	return c
+}
+
+// Collector is a prometheus.Collector counting the errors rendered by httperr.Renderer, labeled with the code of the
+// chain and the response status. Register it with a prometheus.Registerer, and supply its Observer to the Renderer
+// with httperr.WithObserver.
+type Collector struct {
+	namespace string
+	codes     map[string]struct{}
+	catalog   *stderr.Catalog
+	counter   *prometheus.CounterVec
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.counter.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.counter.Collect(ch)
+}
+
+// Observer returns a httperr.Observer incrementing the counter for every rendered error.
+func (c *Collector) Observer() httperr.Observer {
+	return func(_ *http.Request, status int, err error) {
+		c.counter.WithLabelValues(c.code(err), strconv.Itoa(status)).Inc()
+	}
+}
+
+// code returns the label value for the code of the chain, which is empty if the chain has no code.
+func (c *Collector) code(err error) string {
+	var ce *stderr.CodeError
+	if !errors.As(err, &ce) {
+		return ""
+	}
+
+	code := ce.Code()
+
+	switch {
+	case c.codes == nil && c.catalog == nil:
+		if _, ok := stderr.Lookup(code); ok {
+			return code
+		}
+	default:
+		if _, ok := c.codes[code]; ok {
+			return code
+		}
+		if c.catalog != nil {
+			if _, ok := c.catalog.Lookup(code); ok {
+				return code
+			}
+		}
+	}
+
+	return OtherCode
+}
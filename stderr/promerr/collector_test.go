@@ -0,0 +1,80 @@
+package promerr_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/httperr"
+	"github.com/absurdlab/pkg/stderr/promerr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	cases := []struct {
+		name    string
+		options []promerr.Option
+		global  *stderr.Catalog
+		expect  string
+	}{
+		{
+			name:    "codes",
+			options: []promerr.Option{promerr.WithCodes("not_found")},
+			expect: `
+stderr_http_errors_total{code="",status="500"} 1
+stderr_http_errors_total{code="not_found",status="404"} 2
+stderr_http_errors_total{code="other",status="409"} 1
+`,
+		},
+		{
+			name:    "catalog",
+			options: []promerr.Option{promerr.WithCatalog(stderr.NewCatalog(stderr.Definition{Code: "conflict"}))},
+			expect: `
+stderr_http_errors_total{code="",status="500"} 1
+stderr_http_errors_total{code="conflict",status="409"} 1
+stderr_http_errors_total{code="other",status="404"} 2
+`,
+		},
+		{
+			name:   "global catalog",
+			global: stderr.NewCatalog(stderr.Definition{Code: "not_found"}),
+			expect: `
+stderr_http_errors_total{code="",status="500"} 1
+stderr_http_errors_total{code="not_found",status="404"} 2
+stderr_http_errors_total{code="other",status="409"} 1
+`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stderr.SetCatalog(c.global)
+			defer stderr.SetCatalog(nil)
+
+			collector := promerr.New(c.options...)
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(collector)
+
+			renderer := httperr.New(httperr.WithObserver(collector.Observer()))
+			for _, err := range []error{
+				stderr.Chain(stderr.Status(404), stderr.Code("not_found")),
+				stderr.Chain(stderr.Status(404), stderr.Code("not_found")),
+				stderr.Chain(stderr.Status(409), stderr.Code("conflict")),
+				errors.New("boom"),
+			} {
+				renderer.Render(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), err)
+			}
+
+			expect := `
+# HELP stderr_http_errors_total Number of errors rendered, by error code and response status.
+# TYPE stderr_http_errors_total counter` + c.expect
+			if err := testutil.GatherAndCompare(registry, strings.NewReader(expect)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package stderr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"sync"
+)
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]func() Marshaler{
+		TypeStatus:      func() Marshaler { return new(StatusError) },
+		TypeCode:        func() Marshaler { return new(CodeError) },
+		TypeMessage:     func() Marshaler { return new(MessageError) },
+		TypeMessageKey:  func() Marshaler { return new(MessageKeyError) },
+		TypeParams:      func() Marshaler { return new(ParamsError) },
+		TypeGeneric:     func() Marshaler { return new(GenericError) },
+		TypeStack:       func() Marshaler { return new(StackError) },
+		TypeAggregate:   func() Marshaler { return new(AggregateError) },
+		TypeViolations:  func() Marshaler { return new(ViolationsError) },
+		TypeRetryable:   func() Marshaler { return new(RetryableError) },
+		TypeCorrelation: func() Marshaler { return new(CorrelationError) },
+		TypeTemplate:    func() Marshaler { return new(TemplateError) },
+	}
+)
+
+// RegisterType registers the factory of a Marshaler under the node type name, so that FromView can restore nodes
+// of this type. The factory must return a new zero valued instance, on which UnmarshalJSON is called with the node
+// data. RegisterType is usually called during package initialization. It panics if the name is empty, the factory
+// is nil, or the name is already registered, including the names of types provided by this package.
+func RegisterType(name string, factory func() Marshaler) {
+	if len(name) == 0 {
+		panic("type name is required")
+	}
+	if factory == nil {
+		panic("type factory is required")
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic("type " + name + " is already registered")
+	}
+
+	registry[name] = factory
+}
+
+func lookupType(name string) (func() Marshaler, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+type sentinel struct {
+	id  string
+	err error
+}
+
+var (
+	sentinelLock sync.RWMutex
+	sentinels    []sentinel
+)
+
+func init() {
+	RegisterSentinel("io.EOF", io.EOF)
+	RegisterSentinel("io.ErrUnexpectedEOF", io.ErrUnexpectedEOF)
+	RegisterSentinel("context.Canceled", context.Canceled)
+	RegisterSentinel("context.DeadlineExceeded", context.DeadlineExceeded)
+	RegisterSentinel("fs.ErrNotExist", fs.ErrNotExist)
+	RegisterSentinel("fs.ErrExist", fs.ErrExist)
+	RegisterSentinel("fs.ErrPermission", fs.ErrPermission)
+}
+
+// RegisterSentinel registers the sentinel error under the stable identifier. Generic typed nodes record the
+// identifier of the first registered sentinel their error matches with errors.Is, so that FromView restores an error
+// which is the sentinel, or wraps it, and errors.Is checks keep working across services registering the same
+// identifiers. The io.EOF, io.ErrUnexpectedEOF, context.Canceled, context.DeadlineExceeded, fs.ErrNotExist,
+// fs.ErrExist and fs.ErrPermission sentinels are registered under their qualified names, for instance "io.EOF".
+// RegisterSentinel is usually called during package initialization. It panics if the id is empty, the error is nil,
+// or the id is already registered.
+func RegisterSentinel(id string, err error) {
+	if len(id) == 0 {
+		panic("sentinel id is required")
+	}
+	if err == nil {
+		panic("sentinel error is required")
+	}
+
+	sentinelLock.Lock()
+	defer sentinelLock.Unlock()
+
+	for _, s := range sentinels {
+		if s.id == id {
+			panic("sentinel " + id + " is already registered")
+		}
+	}
+
+	sentinels = append(sentinels, sentinel{id: id, err: err})
+}
+
+// sentinelID returns the identifier of the first registered sentinel matched by the error.
+func sentinelID(err error) (string, bool) {
+	sentinelLock.RLock()
+	defer sentinelLock.RUnlock()
+
+	for _, s := range sentinels {
+		if errors.Is(err, s.err) {
+			return s.id, true
+		}
+	}
+
+	return "", false
+}
+
+func lookupSentinel(id string) (error, bool) {
+	sentinelLock.RLock()
+	defer sentinelLock.RUnlock()
+
+	for _, s := range sentinels {
+		if s.id == id {
+			return s.err, true
+		}
+	}
+
+	return nil, false
+}
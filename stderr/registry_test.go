@@ -0,0 +1,81 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func init() {
+	stderr.RegisterType("quota", func() stderr.Marshaler { return new(quotaError) })
+}
+
+func TestRegisterType(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(429),
+		&quotaError{Limit: 10},
+		errors.New("too many requests"),
+	)
+
+	view := stderr.ToView(err)
+	if expect, actual := "quota", view.Context[1].Type; expect != actual {
+		t.Fatalf("expect %s, actual %s", expect, actual)
+	}
+
+	jsonBytes, e := json.Marshal(view)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	decoded := new(stderr.View)
+	if e := json.Unmarshal(jsonBytes, decoded); e != nil {
+		t.Fatal(e)
+	}
+
+	err2 := stderr.FromView(decoded)
+
+	var quota *quotaError
+	if !errors.As(err2, &quota) {
+		t.Fatal("expect recovered error to have quota error in chain")
+	}
+	if expect, actual := 10, quota.Limit; expect != actual {
+		t.Errorf("expect %d, actual %d", expect, actual)
+	}
+	if !errors.Is(err2, stderr.Status(429)) {
+		t.Error("expect recovered error to have status error in chain")
+	}
+}
+
+func TestRegisterType_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect registering a duplicate type to panic")
+		}
+	}()
+
+	stderr.RegisterType(stderr.TypeStatus, func() stderr.Marshaler { return new(quotaError) })
+}
+
+type quotaError struct {
+	Limit int `json:"limit"`
+}
+
+func (e *quotaError) Error() string {
+	return fmt.Sprintf("quota exceeded: %d", e.Limit)
+}
+
+func (e *quotaError) MarshalNode() (*stderr.Node, error) {
+	jsonBytes, err := json.Marshal(*e)
+	if err != nil {
+		return nil, err
+	}
+	return &stderr.Node{Type: "quota", Data: jsonBytes}, nil
+}
+
+func (e *quotaError) UnmarshalJSON(bytes []byte) error {
+	type plain quotaError
+	return json.Unmarshal(bytes, (*plain)(e))
+}
@@ -0,0 +1,156 @@
+package stderr
+
+import (
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Backoff hints at the strategy a caller should use to space out retries.
+type Backoff string
+
+const (
+	// BackoffConstant suggests retrying at a constant interval, usually the retry-after duration.
+	BackoffConstant Backoff = "constant"
+	// BackoffExponential suggests retrying at exponentially growing intervals, starting with the retry-after
+	// duration if present.
+	BackoffExponential Backoff = "exponential"
+)
+
+var retryableStatuses atomic.Pointer[map[int]struct{}]
+
+func init() {
+	SetRetryableStatus(429, 503)
+}
+
+// RetryOption configures the RetryableError returned by Retryable.
+type RetryOption func(e *RetryableError)
+
+// WithRetryAfter provides a RetryOption to suggest the minimum duration to wait before retrying. Non-positive
+// durations are ignored.
+func WithRetryAfter(d time.Duration) RetryOption {
+	return func(e *RetryableError) {
+		if d > 0 {
+			e.after = d
+		}
+	}
+}
+
+// WithBackoff provides a RetryOption to hint at the strategy to space out retries.
+func WithBackoff(backoff Backoff) RetryOption {
+	return func(e *RetryableError) {
+		e.backoff = backoff
+	}
+}
+
+// Retryable returns a retryable typed error. When placed in a chain of errors, this type of error suggests that the
+// failed operation is temporary and worth retrying, optionally after a duration and with a backoff strategy.
+func Retryable(options ...RetryOption) Error {
+	e := new(RetryableError)
+	for _, opt := range options {
+		opt(e)
+	}
+	return e
+}
+
+// IsRetryable returns true if the error chain contains a retryable typed error, or if the first status typed error
+// in the chain has a retryable status. By default, 429 and 503 are retryable statuses, which can be changed by
+// SetRetryableStatus.
+//
+// Only the first status is consulted, as it is the status the chain is rendered with: an inner 503 wrapped under an
+// outer 500 is not retryable, unless the chain also contains a retryable typed error.
+func IsRetryable(err error) bool {
+	var retryable *RetryableError
+	if errors.As(err, &retryable) {
+		return true
+	}
+
+	var status *StatusError
+	if errors.As(err, &status) {
+		_, ok := (*retryableStatuses.Load())[status.Status()]
+		return ok
+	}
+
+	return false
+}
+
+// RetryAfter returns the duration suggested by the first retryable typed error in the chain, and false if the chain
+// does not suggest one.
+func RetryAfter(err error) (time.Duration, bool) {
+	var retryable *RetryableError
+	if errors.As(err, &retryable) && retryable.after > 0 {
+		return retryable.after, true
+	}
+	return 0, false
+}
+
+// SetRetryableStatus sets the global statuses which IsRetryable considers retryable. It is safe for concurrent use
+// with IsRetryable.
+func SetRetryableStatus(statuses ...int) {
+	temp := make(map[int]struct{}, len(statuses))
+	for _, s := range statuses {
+		temp[s] = struct{}{}
+	}
+	retryableStatuses.Store(&temp)
+}
+
+type RetryableError struct {
+	after   time.Duration
+	backoff Backoff
+}
+
+func (e *RetryableError) RetryAfter() time.Duration {
+	return e.after
+}
+
+func (e *RetryableError) Backoff() Backoff {
+	return e.backoff
+}
+
+func (e *RetryableError) Error() string {
+	if e.after > 0 {
+		return "retryable: after " + e.after.String()
+	}
+	return "retryable"
+}
+
+// Is returns true for any retryable typed target, so that errors.Is(err, Retryable()) reports whether the chain is
+// explicitly marked as retryable.
+func (e *RetryableError) Is(target error) bool {
+	_, ok := target.(*RetryableError)
+	return ok
+}
+
+func (e *RetryableError) MarshalNode() (*Node, error) {
+	jsonBytes, err := json.Marshal(retryableErrorJSON{
+		RetryAfter: e.after.Seconds(),
+		Backoff:    e.backoff,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Type: TypeRetryable,
+		Data: jsonBytes,
+	}, nil
+}
+
+func (e *RetryableError) UnmarshalJSON(bytes []byte) error {
+	var temp retryableErrorJSON
+	if err := json.Unmarshal(bytes, &temp); err != nil {
+		return err
+	}
+
+	e.after = time.Duration(temp.RetryAfter * float64(time.Second))
+	e.backoff = temp.Backoff
+
+	return nil
+}
+
+type retryableErrorJSON struct {
+	// RetryAfter is in seconds.
+	RetryAfter float64 `json:"retry_after,omitempty"`
+	Backoff    Backoff `json:"backoff,omitempty"`
+}
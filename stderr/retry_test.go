@@ -0,0 +1,65 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{name: "retryable", err: stderr.Chain(stderr.Code("busy"), stderr.Retryable()), retryable: true},
+		{name: "429", err: stderr.Chain(stderr.Status(429), stderr.Code("too_many_requests")), retryable: true},
+		{name: "503", err: stderr.Status(503), retryable: true},
+		{name: "400", err: stderr.Chain(stderr.Status(400), stderr.Code("invalid_item")), retryable: false},
+		{name: "inner 503", err: stderr.Chain(stderr.Status(500), stderr.Status(503)), retryable: false},
+		{name: "generic", err: errors.New("foo"), retryable: false},
+		{name: "nil", err: nil, retryable: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if expect, actual := c.retryable, stderr.IsRetryable(c.err); expect != actual {
+				t.Errorf("expect %v, actual %v", expect, actual)
+			}
+		})
+	}
+}
+
+func TestRetryable_View(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(503),
+		stderr.Retryable(stderr.WithRetryAfter(1500*time.Millisecond), stderr.WithBackoff(stderr.BackoffExponential)),
+	)
+
+	jsonBytes, jsonErr := json.Marshal(stderr.ToView(err))
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	view := new(stderr.View)
+	if jsonErr = json.Unmarshal(jsonBytes, view); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	var retryable *stderr.RetryableError
+	if !errors.As(stderr.FromView(view), &retryable) {
+		t.Fatal("expect retryable error in restored chain")
+	}
+	if expect, actual := 1500*time.Millisecond, retryable.RetryAfter(); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := stderr.BackoffExponential, retryable.Backoff(); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if !errors.Is(err, stderr.Retryable()) {
+		t.Error("expect chain to be marked as retryable")
+	}
+}
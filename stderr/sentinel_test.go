@@ -0,0 +1,86 @@
+package stderr_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func init() {
+	stderr.RegisterSentinel("sql.ErrNoRows", sql.ErrNoRows)
+}
+
+func TestRegisterSentinel(t *testing.T) {
+	_, pathErr := os.Open("testdata/does_not_exist")
+
+	cases := []struct {
+		name   string
+		err    error
+		target error
+		text   string
+	}{
+		{name: "registered", err: sql.ErrNoRows, target: sql.ErrNoRows, text: "sql: no rows in result set"},
+		{name: "wrapped", err: fmt.Errorf("find item: %w", sql.ErrNoRows), target: sql.ErrNoRows, text: "find item: sql: no rows in result set"},
+		{name: "builtin", err: io.EOF, target: io.EOF, text: "EOF"},
+		{name: "context", err: context.DeadlineExceeded, target: context.DeadlineExceeded, text: "context deadline exceeded"},
+		{name: "matched by Is", err: pathErr, target: os.ErrNotExist, text: pathErr.Error()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			jsonBytes, err := json.Marshal(stderr.ToView(stderr.Chain(stderr.Status(404), c.err)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			view := new(stderr.View)
+			if err = json.Unmarshal(jsonBytes, view); err != nil {
+				t.Fatal(err)
+			}
+
+			restored := stderr.FromView(view)
+			if !errors.Is(restored, c.target) {
+				t.Errorf("expect %v in restored chain", c.target)
+			}
+
+			var generic *stderr.GenericError
+			if !errors.As(restored, &generic) {
+				t.Fatal("expect generic error in restored chain")
+			}
+			if expect, actual := c.text, generic.Error(); expect != actual {
+				t.Errorf("expect %v, actual %v", expect, actual)
+			}
+		})
+	}
+}
+
+func TestRegisterSentinel_Unknown(t *testing.T) {
+	view := &stderr.View{Context: []*stderr.Node{
+		{Type: stderr.TypeGeneric, Data: json.RawMessage(`{"error":"boom","sentinel":"unknown.ErrBoom"}`)},
+	}}
+
+	var generic *stderr.GenericError
+	if !errors.As(stderr.FromView(view), &generic) {
+		t.Fatal("expect generic error in restored chain")
+	}
+	if expect, actual := "boom", generic.Error(); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}
+
+func TestRegisterSentinel_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect registering a duplicate sentinel to panic")
+		}
+	}()
+
+	stderr.RegisterSentinel("io.EOF", errors.New("EOF"))
+}
@@ -0,0 +1,107 @@
+// Package slogerr provides a slog.Handler middleware that logs stderr error chains as structured attributes.
+package slogerr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+// Option configures the Handler.
+type Option func(h *Handler)
+
+// WithGroup provides an Option to log error chains under the group name, instead of the key of the attribute holding
+// the error. An empty name inlines the attributes of the chain into the enclosing group.
+func WithGroup(name string) Option {
+	return func(h *Handler) {
+		h.group = &name
+	}
+}
+
+// WithPolicy provides an Option to apply the stderr.Policy to the chain before logging, for instance to hide the
+// generic causes with stderr.PublicPolicy or a stderr.Redactor.
+func WithPolicy(policy *stderr.Policy) Option {
+	return func(h *Handler) {
+		h.policy = policy
+	}
+}
+
+// New wraps the slog.Handler in a Handler.
+func New(next slog.Handler, options ...Option) *Handler {
+	h := &Handler{next: next}
+	for _, opt := range options {
+		opt(h)
+	}
+	return h
+}
+
+// Handler is a slog.Handler middleware. Attributes holding an error chain, that is an error containing any stderr
+// error, are replaced by a group of the status, code, message, params and generic cause of the chain, as described
+// by stderr.View LogValue, before passing on to the next slog.Handler. Other attributes are passed on as is.
+type Handler struct {
+	next   slog.Handler
+	group  *string
+	policy *stderr.Policy
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	converted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		converted.AddAttrs(h.convert(a))
+		return true
+	})
+	return h.next.Handle(ctx, converted)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	converted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		converted = append(converted, h.convert(a))
+	}
+	return &Handler{next: h.next.WithAttrs(converted), group: h.group, policy: h.policy}
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), group: h.group, policy: h.policy}
+}
+
+func (h *Handler) convert(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		converted := make([]slog.Attr, 0, len(group))
+		for _, each := range group {
+			converted = append(converted, h.convert(each))
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(converted...)}
+	case slog.KindAny, slog.KindLogValuer:
+		err, ok := a.Value.Any().(error)
+		if !ok || !isChain(err) {
+			return a
+		}
+
+		key := a.Key
+		if h.group != nil {
+			key = *h.group
+		}
+
+		return slog.Attr{Key: key, Value: stderr.ToView(err).Apply(h.policy).LogValue()}
+	default:
+		return a
+	}
+}
+
+func isChain(err error) bool {
+	var target stderr.Error
+	return errors.As(err, &target)
+}
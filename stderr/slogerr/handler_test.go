@@ -0,0 +1,90 @@
+package slogerr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/slogerr"
+)
+
+func TestHandler(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(400),
+		stderr.Code("invalid_item"),
+		stderr.Message("item is invalid"),
+		stderr.Params("id", "42"),
+		errors.New("sql: no rows in result set"),
+	)
+
+	cases := []struct {
+		name    string
+		options []slogerr.Option
+		run     func(t *testing.T, entry map[string]interface{})
+	}{
+		{
+			name: "attribute key",
+			run: func(t *testing.T, entry map[string]interface{}) {
+				group, ok := entry["err"].(map[string]interface{})
+				if !ok {
+					t.Fatalf("expect err group, got %v", entry)
+				}
+				if expect, actual := float64(400), group["status"]; expect != actual {
+					t.Errorf("expect %v, actual %v", expect, actual)
+				}
+				if expect, actual := "invalid_item", group["code"]; expect != actual {
+					t.Errorf("expect %v, actual %v", expect, actual)
+				}
+				if expect, actual := "42", group["params"].(map[string]interface{})["id"]; expect != actual {
+					t.Errorf("expect %v, actual %v", expect, actual)
+				}
+				if expect, actual := "sql: no rows in result set", group["cause"]; expect != actual {
+					t.Errorf("expect %v, actual %v", expect, actual)
+				}
+				if expect, actual := "bar", entry["foo"]; expect != actual {
+					t.Errorf("expect other attributes to be intact, got %v", actual)
+				}
+			},
+		},
+		{
+			name:    "group and policy",
+			options: []slogerr.Option{slogerr.WithGroup("error"), slogerr.WithPolicy(stderr.PublicPolicy())},
+			run: func(t *testing.T, entry map[string]interface{}) {
+				group, ok := entry["error"].(map[string]interface{})
+				if !ok {
+					t.Fatalf("expect error group, got %v", entry)
+				}
+				if _, ok := group["cause"]; ok {
+					t.Error("expect cause to be hidden")
+				}
+			},
+		},
+		{
+			name:    "inline",
+			options: []slogerr.Option{slogerr.WithGroup("")},
+			run: func(t *testing.T, entry map[string]interface{}) {
+				if expect, actual := "invalid_item", entry["code"]; expect != actual {
+					t.Errorf("expect %v, actual %v", expect, actual)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			logger := slog.New(slogerr.New(slog.NewJSONHandler(buf, nil), c.options...))
+			logger.Error("request failed", "err", err, "foo", "bar")
+
+			var entry map[string]interface{}
+			if e := json.Unmarshal(buf.Bytes(), &entry); e != nil {
+				t.Fatal(e)
+			}
+
+			c.run(t, entry)
+		})
+	}
+}
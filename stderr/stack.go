@@ -0,0 +1,102 @@
+package stderr
+
+import (
+	"encoding/json"
+	"runtime"
+)
+
+// maxStackDepth is the maximum number of frames recorded by a stack typed error.
+const maxStackDepth = 32
+
+// Stack returns a stack typed error recording the frames of its caller. When placed in a chain of errors, this type
+// of error tells where the error originated. The frames are printed with the %+v verb, and are serialized into View
+// as a context node of TypeStack, which may be hidden from external clients when rendering.
+func Stack() Error {
+	return callers(3)
+}
+
+// WithStack chains a stack typed error recording the frames of its caller after the error, so that the chain keeps
+// the message of the error. It returns nil if the error is nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return Chain(err, callers(3))
+}
+
+// callers records the stack frames, skipping the given number of frames, with 0 identifying runtime.Callers, and
+// 1 identifying callers itself.
+func callers(skip int) *StackError {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return &StackError{frames: []Frame{}}
+	}
+
+	var (
+		frames = runtime.CallersFrames(pcs[:n])
+		result = make([]Frame, 0, n)
+	)
+	for {
+		frame, more := frames.Next()
+		result = append(result, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+
+	return &StackError{frames: result}
+}
+
+// Frame is a single stack frame recorded by a stack typed error.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+type StackError struct {
+	frames []Frame
+}
+
+func (e *StackError) Frames() []Frame {
+	return e.frames
+}
+
+func (e *StackError) Error() string {
+	if len(e.frames) == 0 {
+		return "stack: <empty>"
+	}
+	return "stack: " + e.frames[0].Function
+}
+
+func (e *StackError) MarshalNode() (*Node, error) {
+	jsonBytes, err := json.Marshal(stackErrorJSON{Frames: e.frames})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Type: TypeStack,
+		Data: jsonBytes,
+	}, nil
+}
+
+func (e *StackError) UnmarshalJSON(bytes []byte) error {
+	var temp stackErrorJSON
+	if err := json.Unmarshal(bytes, &temp); err != nil {
+		return err
+	}
+
+	e.frames = temp.Frames
+
+	return nil
+}
+
+type stackErrorJSON struct {
+	Frames []Frame `json:"frames"`
+}
@@ -0,0 +1,69 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestWithStack(t *testing.T) {
+	err := stderr.WithStack(stderr.Chain(stderr.Status(500), errors.New("boom")))
+
+	var stack *stderr.StackError
+	if !errors.As(err, &stack) {
+		t.Fatal("expect stack error in chain")
+	}
+	if len(stack.Frames()) == 0 {
+		t.Fatal("expect frames to be recorded")
+	}
+	if expect, actual := "stderr_test.TestWithStack", stack.Frames()[0].Function; !strings.HasSuffix(actual, expect) {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+
+	printed := fmt.Sprintf("%+v", err)
+	if !strings.Contains(printed, "stack_test.go") {
+		t.Errorf("expect frames to be printed, got %s", printed)
+	}
+	if !strings.Contains(printed, "boom") {
+		t.Errorf("expect cause to be printed, got %s", printed)
+	}
+
+	for _, cause := range []error{errors.New("db down"), stderr.Chain(stderr.Status(500), errors.New("boom"))} {
+		if expect, actual := cause.Error(), stderr.WithStack(cause).Error(); expect != actual {
+			t.Errorf("expect %s, actual %s", expect, actual)
+		}
+	}
+
+	if stderr.WithStack(nil) != nil {
+		t.Error("expect nil error to remain nil")
+	}
+}
+
+func TestStack_View(t *testing.T) {
+	view := stderr.ToView(stderr.Chain(stderr.Stack(), errors.New("boom")))
+	if expect, actual := stderr.TypeStack, view.Context[0].Type; expect != actual {
+		t.Fatalf("expect %s, actual %s", expect, actual)
+	}
+
+	jsonBytes, err := json.Marshal(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := new(stderr.View)
+	if err := json.Unmarshal(jsonBytes, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	var stack *stderr.StackError
+	if !errors.As(stderr.FromView(decoded), &stack) {
+		t.Fatal("expect recovered error to have stack error in chain")
+	}
+	if expect, actual := "stderr_test.TestStack_View", stack.Frames()[0].Function; !strings.HasSuffix(actual, expect) {
+		t.Errorf("expect %s, actual %s", expect, actual)
+	}
+}
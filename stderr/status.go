@@ -16,7 +16,6 @@ func Status(status int) *StatusError {
 
 type StatusError struct {
 	status int
-	next   Error
 }
 
 func (e *StatusError) Status() int {
@@ -27,10 +26,6 @@ func (e *StatusError) Error() string {
 	return fmt.Sprintf("status: %d", e.status)
 }
 
-func (e *StatusError) Unwrap() error {
-	return e.next
-}
-
 func (e *StatusError) Is(target error) bool {
 	switch se := target.(type) {
 	case *StatusError:
@@ -40,18 +35,14 @@ func (e *StatusError) Is(target error) bool {
 	}
 }
 
-func (e *StatusError) wrap(err Error) {
-	e.next = err
-}
-
-func (e *StatusError) asNode() (*node, error) {
+func (e *StatusError) MarshalNode() (*Node, error) {
 	jsonBytes, err := json.Marshal(statusErrorJSON{Status: e.status})
 	if err != nil {
 		return nil, err
 	}
 
-	return &node{
-		Type: typeStatus,
+	return &Node{
+		Type: TypeStatus,
 		Data: jsonBytes,
 	}, nil
 }
@@ -0,0 +1,116 @@
+// Package stderrtest provides test assertions for stderr error chains.
+package stderrtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+// AssertStatus asserts that the View of the error has the status. It returns true if the assertion holds.
+func AssertStatus(t testing.TB, err error, status int) bool {
+	t.Helper()
+
+	if actual := stderr.ToView(err).Status; actual != status {
+		t.Errorf("expect status %d, actual %d in %v", status, actual, err)
+		return false
+	}
+
+	return true
+}
+
+// AssertCode asserts that the View of the error has the code. It returns true if the assertion holds.
+func AssertCode(t testing.TB, err error, code string) bool {
+	t.Helper()
+
+	if actual := stderr.ToView(err).Code; actual != code {
+		t.Errorf("expect code %q, actual %q in %v", code, actual, err)
+		return false
+	}
+
+	return true
+}
+
+// AssertMessage asserts that the View of the error has the message, after template interpolation. It returns true if
+// the assertion holds.
+func AssertMessage(t testing.TB, err error, message string) bool {
+	t.Helper()
+
+	if actual := stderr.ToView(err).Message; actual != message {
+		t.Errorf("expect message %q, actual %q in %v", message, actual, err)
+		return false
+	}
+
+	return true
+}
+
+// AssertParam asserts that the params in the chain hold the key with the value. Outer params take precedence over
+// inner ones of the same key. Values are equal if they are deeply equal, or print the same, so that 42 matches params
+// restored from JSON as float64. It returns true if the assertion holds.
+func AssertParam(t testing.TB, err error, key string, value interface{}) bool {
+	t.Helper()
+
+	for _, each := range elements(err) {
+		params, ok := each.(*stderr.ParamsError)
+		if !ok {
+			continue
+		}
+
+		actual, ok := params.Params()[key]
+		if !ok {
+			continue
+		}
+
+		if !reflect.DeepEqual(actual, value) && fmt.Sprint(actual) != fmt.Sprint(value) {
+			t.Errorf("expect param %s to be %v, actual %v in %v", key, value, actual, err)
+			return false
+		}
+
+		return true
+	}
+
+	t.Errorf("expect param %s in %v", key, err)
+	return false
+}
+
+// AssertChainShape asserts that the context node types of the View of the error are exactly the types, in order, for
+// instance stderr.TypeStatus, stderr.TypeCode, stderr.TypeGeneric. It returns true if the assertion holds.
+func AssertChainShape(t testing.TB, err error, types ...string) bool {
+	t.Helper()
+
+	var actual []string
+	for _, n := range stderr.ToView(err).Context {
+		actual = append(actual, n.Type)
+	}
+
+	if strings.Join(actual, ",") != strings.Join(types, ",") {
+		t.Errorf("expect chain shape [%s], actual [%s]", strings.Join(types, " "), strings.Join(actual, " "))
+		return false
+	}
+
+	return true
+}
+
+// elements returns the top level errors in the chain. The branches of aggregate typed errors are not descended into.
+func elements(err error) []error {
+	if _, ok := err.(*stderr.AggregateError); ok {
+		return []error{err}
+	}
+
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		var results []error
+		for _, each := range multi.Unwrap() {
+			results = append(results, elements(each)...)
+		}
+		return results
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return []error{err}
+}
@@ -0,0 +1,103 @@
+package stderrtest_test
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/stderrtest"
+)
+
+func TestAssert(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(404),
+		stderr.Code("not_found"),
+		stderr.Template("item {id} is not found"),
+		stderr.Params("id", "42"),
+		stderr.Chain(stderr.Params("id", "0", "count", 3), errors.New("sql: no rows in result set")),
+	)
+
+	cases := []struct {
+		name   string
+		assert func(t testing.TB) bool
+		pass   bool
+	}{
+		{name: "status", assert: func(t testing.TB) bool { return stderrtest.AssertStatus(t, err, 404) }, pass: true},
+		{name: "wrong status", assert: func(t testing.TB) bool { return stderrtest.AssertStatus(t, err, 400) }},
+		{name: "code", assert: func(t testing.TB) bool { return stderrtest.AssertCode(t, err, "not_found") }, pass: true},
+		{name: "wrong code", assert: func(t testing.TB) bool { return stderrtest.AssertCode(t, err, "conflict") }},
+		{name: "message", assert: func(t testing.TB) bool { return stderrtest.AssertMessage(t, err, "item 42 is not found") }, pass: true},
+		{name: "outer param", assert: func(t testing.TB) bool { return stderrtest.AssertParam(t, err, "id", "42") }, pass: true},
+		{name: "inner param", assert: func(t testing.TB) bool { return stderrtest.AssertParam(t, err, "count", 3) }, pass: true},
+		{name: "wrong param", assert: func(t testing.TB) bool { return stderrtest.AssertParam(t, err, "id", "0") }},
+		{name: "missing param", assert: func(t testing.TB) bool { return stderrtest.AssertParam(t, err, "store", "main") }},
+		{
+			name: "shape",
+			assert: func(t testing.TB) bool {
+				return stderrtest.AssertChainShape(t, err,
+					stderr.TypeStatus, stderr.TypeCode, stderr.TypeTemplate, stderr.TypeParams, stderr.TypeParams,
+					stderr.TypeGeneric)
+			},
+			pass: true,
+		},
+		{
+			name:   "wrong shape",
+			assert: func(t testing.TB) bool { return stderrtest.AssertChainShape(t, err, stderr.TypeStatus) },
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if expect, actual := c.pass, c.assert(r); expect != actual {
+				t.Errorf("expect %v, actual %v", expect, actual)
+			}
+			if expect, actual := !c.pass, r.failed; expect != actual {
+				t.Errorf("expect %v, actual %v", expect, actual)
+			}
+		})
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	err := stderr.WithStack(stderr.Chain(
+		stderr.Status(404),
+		stderr.Code("not_found"),
+		stderr.Params("id", "42"),
+		errors.New("sql: no rows in result set"),
+	))
+
+	stderrtest.AssertGolden(t, err, filepath.Join("testdata", "not_found.json"))
+
+	if f := flag.Lookup("stderrtest.update"); f != nil && f.Value.String() == "true" {
+		return
+	}
+
+	r := &recorder{TB: t}
+	if stderrtest.AssertGolden(r, stderr.Chain(stderr.Status(404), stderr.Code("gone")), filepath.Join("testdata", "not_found.json")) {
+		t.Error("expect mismatch")
+	}
+	if !strings.Contains(r.message, `-   "error": "not_found",`) || !strings.Contains(r.message, `+   "error": "gone"`) {
+		t.Errorf("expect readable diff, got %s", r.message)
+	}
+}
+
+// recorder records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
@@ -0,0 +1,124 @@
+package stderrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+var update = flag.Bool("stderrtest.update", false, "update golden files of stderrtest.AssertGolden")
+
+// GoldenOption configures AssertGolden.
+type GoldenOption func(c *goldenConfig)
+
+// WithPolicy provides a GoldenOption to apply the stderr.Policy to the View before comparison, instead of the default
+// policy which hides stack typed nodes, whose frames change with every edit of the source.
+func WithPolicy(policy *stderr.Policy) GoldenOption {
+	return func(c *goldenConfig) {
+		c.policy = policy
+	}
+}
+
+type goldenConfig struct {
+	policy *stderr.Policy
+}
+
+// AssertGolden asserts that the indented JSON encoding of the View of the error equals the content of the golden
+// file. On mismatch, a line diff from the golden file to the actual View is reported. When the test binary runs with
+// the -stderrtest.update flag, the golden file is written with the actual View instead. It returns true if the
+// assertion holds.
+func AssertGolden(t testing.TB, err error, path string, options ...GoldenOption) bool {
+	t.Helper()
+
+	c := &goldenConfig{
+		policy: &stderr.Policy{
+			Redactors: map[string]stderr.Redactor{
+				stderr.TypeStack: func(n *stderr.Node) *stderr.Node { return nil },
+			},
+		},
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+
+	actual, e := json.MarshalIndent(stderr.ToView(err).Apply(c.policy), "", "  ")
+	if e != nil {
+		t.Fatalf("marshal view: %s", e)
+		return false
+	}
+	actual = append(actual, '\n')
+
+	if *update {
+		if e := os.MkdirAll(filepath.Dir(path), 0o755); e != nil {
+			t.Fatalf("update golden file: %s", e)
+			return false
+		}
+		if e := os.WriteFile(path, actual, 0o644); e != nil {
+			t.Fatalf("update golden file: %s", e)
+			return false
+		}
+		return true
+	}
+
+	expect, e := os.ReadFile(path)
+	if e != nil {
+		t.Fatalf("read golden file: %s (run with -stderrtest.update to create it)", e)
+		return false
+	}
+
+	if !bytes.Equal(expect, actual) {
+		t.Errorf("view does not match golden file %s:\n%s", path, diff(string(expect), string(actual)))
+		return false
+	}
+
+	return true
+}
+
+// diff returns a line diff from expect to actual. Removed lines are prefixed with "-", added lines with "+", and
+// common lines with a space.
+func diff(expect, actual string) string {
+	var (
+		a = strings.Split(strings.TrimSuffix(expect, "\n"), "\n")
+		b = strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+	)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			sb.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,219 @@
+package stderr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeLayouts = map[string]string{
+		"date":     time.DateOnly,
+		"time":     time.TimeOnly,
+		"datetime": time.DateTime,
+		"rfc3339":  time.RFC3339,
+	}
+	durationUnits = map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"us": time.Microsecond,
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+	}
+)
+
+// Template returns a template typed error. When placed in a chain of errors, this type of error suggests the
+// human-readable message to include in the API response, like a message typed error, except that {name} placeholders
+// in the template are interpolated with the params in the same chain when the View is created. The template and the
+// params remain available in context for clients that render their own text. See Interpolate for the placeholder
+// syntax. The supplied template must not be empty.
+func Template(template string) Error {
+	if len(template) == 0 {
+		panic("template is required")
+	}
+	return &TemplateError{template: template}
+}
+
+type TemplateError struct {
+	template string
+}
+
+func (e *TemplateError) Template() string {
+	return e.template
+}
+
+func (e *TemplateError) Is(_ error) bool {
+	return false
+}
+
+func (e *TemplateError) Error() string {
+	return e.template
+}
+
+func (e *TemplateError) MarshalNode() (*Node, error) {
+	jsonBytes, err := json.Marshal(templateErrorJSON{Template: e.template})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Type: TypeTemplate,
+		Data: jsonBytes,
+	}, nil
+}
+
+func (e *TemplateError) UnmarshalJSON(bytes []byte) error {
+	var temp templateErrorJSON
+	if err := json.Unmarshal(bytes, &temp); err != nil {
+		return err
+	}
+
+	e.template = temp.Template
+
+	return nil
+}
+
+type templateErrorJSON struct {
+	Template string `json:"template"`
+}
+
+// Interpolate replaces {name} placeholders in the text with the params. A placeholder may specify a format after a
+// colon, as in {name:format}:
+//
+//   - a format starting with % is a fmt verb, for instance {price:%.2f}. Integer verbs accept integral floats, which
+//     is how JSON decodes numbers.
+//   - for time.Time params, or strings in RFC 3339 format, the format is a time layout, or one of date, time, datetime
+//     and rfc3339, for instance {expiry:date}.
+//   - for time.Duration params, or numbers of nanoseconds, the format is the unit to round to, one of ns, us, ms, s, m
+//     and h, for instance {elapsed:s}.
+//
+// Params are otherwise formatted with fmt.Sprint, except integral floats, which are formatted without exponent. Placeholders of unknown params are left as is.
+func Interpolate(text string, params map[string]interface{}) string {
+	var sb strings.Builder
+
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(text[:start])
+
+		name, format, _ := strings.Cut(text[start+1:end], ":")
+		if val, ok := params[name]; ok {
+			sb.WriteString(formatParam(val, format))
+		} else {
+			sb.WriteString(text[start : end+1])
+		}
+
+		text = text[end+1:]
+	}
+
+	sb.WriteString(text)
+
+	return sb.String()
+}
+
+func formatParam(val interface{}, format string) string {
+	if len(format) == 0 {
+		return sprint(val)
+	}
+
+	if strings.HasPrefix(format, "%") {
+		if f, ok := val.(float64); ok && f == math.Trunc(f) && strings.ContainsAny(format[len(format)-1:], "bcdoxX") {
+			return fmt.Sprintf(format, int64(f))
+		}
+		return fmt.Sprintf(format, val)
+	}
+
+	layout, ok := timeLayouts[format]
+	if !ok {
+		layout = format
+	}
+
+	switch v := val.(type) {
+	case time.Time:
+		return v.Format(layout)
+	case time.Duration:
+		if unit, ok := durationUnits[format]; ok {
+			return v.Round(unit).String()
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t.Format(layout)
+		}
+	case float64:
+		if unit, ok := durationUnits[format]; ok {
+			return time.Duration(v).Round(unit).String()
+		}
+	case int64:
+		if unit, ok := durationUnits[format]; ok {
+			return time.Duration(v).Round(unit).String()
+		}
+	case int:
+		if unit, ok := durationUnits[format]; ok {
+			return time.Duration(v).Round(unit).String()
+		}
+	}
+
+	return sprint(val)
+}
+
+// sprint formats the param with fmt.Sprint, except integral floats, which are formatted without exponent, so that
+// integers decoded from JSON as float64 read the same as before encoding.
+func sprint(val interface{}) string {
+	if f, ok := val.(float64); ok && f == math.Trunc(f) && !math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(val)
+}
+
+// message returns the first message typed error, or the first template typed error interpolated with the params in
+// the chain, whichever comes first.
+func message(err error) string {
+	for _, each := range elements(err) {
+		switch e := each.(type) {
+		case *MessageError:
+			return e.Message()
+		case *TemplateError:
+			return Interpolate(e.Template(), chainParams(err))
+		}
+	}
+
+	var me *MessageError
+	if errors.As(err, &me) {
+		return me.Message()
+	}
+
+	return ""
+}
+
+// chainParams merges the params in the chain. Outer params take precedence over inner ones of the same name.
+func chainParams(err error) map[string]interface{} {
+	params := map[string]interface{}{}
+
+	for _, each := range elements(err) {
+		pe, ok := each.(*ParamsError)
+		if !ok {
+			continue
+		}
+		for k, v := range pe.Params() {
+			if _, ok := params[k]; !ok {
+				params[k] = v
+			}
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,97 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestInterpolate(t *testing.T) {
+	expiry := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		text   string
+		params map[string]interface{}
+		expect string
+	}{
+		{text: "item {id} not found in {store}", params: map[string]interface{}{"id": 42, "store": "main"}, expect: "item 42 not found in main"},
+		{text: "item {id} not found", params: map[string]interface{}{}, expect: "item {id} not found"},
+		{text: "price is {price:%.2f}", params: map[string]interface{}{"price": 3.5}, expect: "price is 3.50"},
+		{text: "{count:%03d} left", params: map[string]interface{}{"count": float64(7)}, expect: "007 left"},
+		{text: "expired on {expiry:date}", params: map[string]interface{}{"expiry": expiry}, expect: "expired on 2024-03-01"},
+		{text: "expired at {expiry:15:04}", params: map[string]interface{}{"expiry": expiry}, expect: "expired at 12:30"},
+		{text: "expired on {expiry:date}", params: map[string]interface{}{"expiry": "2024-03-01T12:30:00Z"}, expect: "expired on 2024-03-01"},
+		{text: "took {elapsed:s}", params: map[string]interface{}{"elapsed": 1500 * time.Millisecond}, expect: "took 2s"},
+		{text: "took {elapsed:ms}", params: map[string]interface{}{"elapsed": float64(1500 * time.Microsecond)}, expect: "took 2ms"},
+		{text: "item {id} missing", params: map[string]interface{}{"id": float64(1234567)}, expect: "item 1234567 missing"},
+		{text: "ratio {ratio}", params: map[string]interface{}{"ratio": 0.25}, expect: "ratio 0.25"},
+		{text: "unclosed {id", params: map[string]interface{}{"id": 42}, expect: "unclosed {id"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.text, func(t *testing.T) {
+			if actual := stderr.Interpolate(c.text, c.params); c.expect != actual {
+				t.Errorf("expect %v, actual %v", c.expect, actual)
+			}
+		})
+	}
+}
+
+func TestTemplate_View(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Status(404),
+		stderr.Template("item {id} not found in {store}"),
+		stderr.Params("id", 42),
+		stderr.Chain(stderr.Params("id", 0, "store", "main"), errors.New("sql: no rows in result set")),
+	)
+
+	view := stderr.ToView(err)
+	if expect, actual := "item 42 not found in main", view.Message; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := stderr.TypeTemplate, view.Context[1].Type; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	jsonBytes, jsonErr := json.Marshal(view)
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	decoded := new(stderr.View)
+	if jsonErr = json.Unmarshal(jsonBytes, decoded); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	restored := stderr.FromView(decoded)
+
+	var template *stderr.TemplateError
+	if !errors.As(restored, &template) {
+		t.Fatal("expect template error in restored chain")
+	}
+	if expect, actual := "item {id} not found in {store}", template.Template(); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+	if expect, actual := "item 42 not found in main", stderr.ToView(restored).Message; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	large := stderr.Chain(stderr.Template("item {id} missing"), stderr.Params("id", 1234567))
+	if jsonBytes, jsonErr = json.Marshal(stderr.ToView(large)); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	decoded = new(stderr.View)
+	if jsonErr = json.Unmarshal(jsonBytes, decoded); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	if expect, actual := "item 1234567 missing", stderr.ToView(stderr.FromView(decoded)).Message; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	if expect, actual := "static", stderr.ToView(stderr.Chain(stderr.Message("static"), stderr.Template("{id}"))).Message; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}
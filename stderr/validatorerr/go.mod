@@ -0,0 +1,21 @@
+module github.com/absurdlab/pkg/stderr/validatorerr
+
+go 1.23.0
+
+require (
+	github.com/absurdlab/pkg/stderr v0.0.0-00010101000000-000000000000
+	github.com/go-playground/validator/v10 v10.26.0
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.8 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	golang.org/x/crypto v0.39.0 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+)
+
+replace github.com/absurdlab/pkg/stderr => ../
@@ -0,0 +1,81 @@
+// Package validatorerr converts the errors of github.com/go-playground/validator into stderr violations.
+package validatorerr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/go-playground/validator/v10"
+)
+
+// Option configures the conversion.
+type Option func(c *config)
+
+// WithMessage provides an Option to describe each failed field with the function, for instance using a translator.
+// By default, violations carry no message.
+func WithMessage(fn func(fe validator.FieldError) string) Option {
+	return func(c *config) {
+		c.message = fn
+	}
+}
+
+// WithoutValue provides an Option to leave out the rejected values, for instance when the validated struct holds
+// secrets.
+func WithoutValue() Option {
+	return func(c *config) {
+		c.withoutValue = true
+	}
+}
+
+type config struct {
+	message      func(fe validator.FieldError) string
+	withoutValue bool
+}
+
+// Violations converts the validator.ValidationErrors in the error to a violations typed error. The field of each
+// violation is the namespace of the failed field without the name of the top level struct, which follows the names
+// supplied to validator.Validate RegisterTagNameFunc, if any. The rule is the validation tag, along with its param if
+// present. Nil is returned if the error does not contain validator.ValidationErrors.
+func Violations(err error, options ...Option) stderr.Error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) || len(validationErrors) == 0 {
+		return nil
+	}
+
+	c := new(config)
+	for _, opt := range options {
+		opt(c)
+	}
+
+	violations := make([]stderr.Violation, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		v := stderr.Violation{
+			Field: field(fe.Namespace()),
+			Rule:  fe.Tag(),
+		}
+
+		if len(fe.Param()) > 0 {
+			v.Rule += "=" + fe.Param()
+		}
+
+		if c.message != nil {
+			v.Message = c.message(fe)
+		}
+
+		if !c.withoutValue {
+			v.Value = fe.Value()
+		}
+
+		violations = append(violations, v)
+	}
+
+	return stderr.Violations(violations...)
+}
+
+func field(namespace string) string {
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
@@ -0,0 +1,77 @@
+package validatorerr_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+	"github.com/absurdlab/pkg/stderr/validatorerr"
+	"github.com/go-playground/validator/v10"
+)
+
+type order struct {
+	Email string `json:"email" validate:"required,email"`
+	Items []item `json:"items" validate:"dive"`
+}
+
+type item struct {
+	Name     string `json:"name" validate:"required"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+func TestViolations(t *testing.T) {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.Split(f.Tag.Get("json"), ",")[0]
+	})
+
+	err := validate.Struct(order{
+		Email: "foo",
+		Items: []item{{Name: "bar", Quantity: 0}},
+	})
+
+	cases := []struct {
+		name    string
+		options []validatorerr.Option
+		expect  []stderr.Violation
+	}{
+		{
+			name: "default",
+			expect: []stderr.Violation{
+				{Field: "email", Rule: "email", Value: "foo"},
+				{Field: "items[0].quantity", Rule: "min=1", Value: 0},
+			},
+		},
+		{
+			name: "message without value",
+			options: []validatorerr.Option{
+				validatorerr.WithoutValue(),
+				validatorerr.WithMessage(func(fe validator.FieldError) string {
+					return fe.Field() + " is invalid"
+				}),
+			},
+			expect: []stderr.Violation{
+				{Field: "email", Rule: "email", Message: "email is invalid"},
+				{Field: "items[0].quantity", Rule: "min=1", Message: "quantity is invalid"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var violations *stderr.ViolationsError
+			if !errors.As(stderr.Chain(stderr.Status(400), validatorerr.Violations(err, c.options...)), &violations) {
+				t.Fatal("expect violations error in chain")
+			}
+			if actual := violations.Violations(); !reflect.DeepEqual(c.expect, actual) {
+				t.Errorf("expect %v, actual %v", c.expect, actual)
+			}
+		})
+	}
+
+	if validatorerr.Violations(errors.New("foo")) != nil {
+		t.Error("expect nil for errors other than validation errors")
+	}
+}
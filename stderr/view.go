@@ -17,13 +17,16 @@ type View struct {
 	Status  int     `json:"status,omitempty" yaml:"status,omitempty"`
 	Code    string  `json:"error,omitempty" yaml:"error,omitempty"`
 	Message string  `json:"message,omitempty" yaml:"message,omitempty"`
-	Context []*node `json:"context,omitempty" yaml:"context,omitempty"`
+	Context []*Node `json:"context,omitempty" yaml:"context,omitempty"`
 }
 
 // With defaults the View with information suggested in the error chain. Traversing down the error chain, the first
 // status error is used as View.Status; the first code error is used as View.Code; the first message error is used
 // as View.Message. And context is collected by all errors in chain, as long as they don't generate an error during
-// collection. By default, this method does not touch the status, code, message and context if they are not zero valued.
+// collection. A template error preceding the first message error is used as View.Message instead, after interpolation
+// with the params in chain. By default, this method does not touch the status, code, message and context if they are
+// not zero valued.
+// When a global Catalog is set, the missing status and message are filled from the definition of the code.
 func (v *View) With(err error) *View {
 	if v.Status == 0 {
 		var status *StatusError
@@ -40,10 +43,11 @@ func (v *View) With(err error) *View {
 	}
 
 	if len(v.Message) == 0 {
-		var message *MessageError
-		if errors.As(err, &message) {
-			v.Message = message.Message()
-		}
+		v.Message = message(err)
+	}
+
+	if c := catalog.Load(); c != nil {
+		c.apply(v, err)
 	}
 
 	if len(v.Context) == 0 {
@@ -61,50 +65,71 @@ func ToView(err error) *View {
 	return new(View).With(err)
 }
 
-// FromView attempts to restore the error chain using data from the context. If context is empty, or an error
-// occurred during the recovery process, it defaults to FromViewWithoutContext.
+// FromView attempts to restore the error chain using data from the context. Nodes of types not registered with
+// RegisterType are skipped. If context is empty, or an error occurred during the recovery process, it defaults to
+// FromViewWithoutContext.
 func FromView(v *View) error {
 	if len(v.Context) == 0 {
 		return FromViewWithoutContext(v)
 	}
 
+	chain, err := fromNodes(v.Context)
+	if err != nil {
+		return FromViewWithoutContext(v)
+	}
+
+	return Chain(chain...)
+}
+
+func fromNodes(nodes []*Node) ([]error, error) {
 	var chain []error
-	{
-		for _, n := range v.Context {
-			if len(n.Data) == 0 {
-				continue
-			}
 
-			var target Error
-
-			switch n.Type {
-			case typeStatus:
-				target = new(StatusError)
-			case typeCode:
-				target = new(CodeError)
-			case typeMessage:
-				target = new(MessageError)
-			case typeParams:
-				target = new(ParamsError)
-			case typeGeneric:
-				target = new(GenericError)
-			default:
-				continue
+	for _, n := range nodes {
+		if n.Type == TypeAggregate {
+			var branches []error
+			for _, child := range n.Children {
+				branch, err := fromNodes(child)
+				if err != nil {
+					return nil, err
+				}
+				if each := Chain(branch...); each != nil {
+					branches = append(branches, each)
+				}
 			}
-
-			if e := json.Unmarshal(n.Data, target); e != nil {
-				return FromViewWithoutContext(v)
+			aggregate := &AggregateError{errs: branches}
+			if len(n.Data) > 0 {
+				if err := json.Unmarshal(n.Data, aggregate); err != nil {
+					return nil, err
+				}
 			}
+			chain = append(chain, aggregate)
+			continue
+		}
+
+		if len(n.Data) == 0 {
+			continue
+		}
 
-			chain = append(chain, target)
+		factory, ok := lookupType(n.Type)
+		if !ok {
+			continue
 		}
+
+		target := factory()
+
+		if err := json.Unmarshal(n.Data, target); err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, target)
 	}
 
-	return Chain(chain...)
+	return chain, nil
 }
 
-// FromViewWithoutContext attempts to recover error chain using only status, code and message. If none of these
-// are available, it returns an error chain containing ErrCorruptedView.
+// FromViewWithoutContext attempts to recover error chain using only status, code and message. Codes that do not meet
+// the error code format are ignored. If none of these are available, it returns an error chain containing
+// ErrCorruptedView.
 func FromViewWithoutContext(v *View) error {
 	var chain []error
 	{
@@ -112,7 +137,7 @@ func FromViewWithoutContext(v *View) error {
 			chain = append(chain, Status(v.Status))
 		}
 
-		if len(v.Code) > 0 {
+		if ValidCode(v.Code) {
 			chain = append(chain, Code(v.Code))
 		}
 
@@ -128,19 +153,23 @@ func FromViewWithoutContext(v *View) error {
 	return Chain(chain...)
 }
 
-type node struct {
-	Type string          `json:"type,omitempty" yaml:"type,omitempty"`
-	Data json.RawMessage `json:"data,omitempty" yaml:"data,omitempty"`
+// Node is the serialized form of a single error in the chain. Type identifies the kind of error, and Data holds its
+// JSON encoded content. Aggregate typed nodes hold the nodes of each of their branches in Children instead. A branch
+// whose nodes are all filtered out, for instance by a Policy, is kept empty, so that branches keep their indices.
+type Node struct {
+	Type     string          `json:"type,omitempty" yaml:"type,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty" yaml:"data,omitempty"`
+	Children [][]*Node       `json:"children,omitempty" yaml:"children,omitempty"`
 }
 
-func collectNodes(err error) ([]*node, error) {
+func collectNodes(err error) ([]*Node, error) {
 	if err == nil {
-		return []*node{}, nil
+		return []*Node{}, nil
 	}
 
-	var results []*node
-	for cur := normalize(err); cur != nil; cur = normalize(cur.Unwrap()) {
-		n, e := cur.asNode()
+	var results []*Node
+	for _, each := range elements(err) {
+		n, e := each.MarshalNode()
 		if e != nil {
 			return nil, e
 		}
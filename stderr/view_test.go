@@ -1,8 +1,10 @@
 package stderr_test
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/absurdlab/pkg/stderr"
+	"reflect"
 	"testing"
 )
 
@@ -38,3 +40,33 @@ func TestView(t *testing.T) {
 		}
 	}
 }
+
+func TestView_Params(t *testing.T) {
+	err := stderr.Chain(
+		stderr.Params("id", int64(1311768467463790321), "ratio", 0.5),
+		stderr.Params("id", 0, "name", "foo"),
+	)
+
+	jsonBytes, jsonErr := json.Marshal(stderr.ToView(err))
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	view := new(stderr.View)
+	if jsonErr = json.Unmarshal(jsonBytes, view); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	expect := map[string]interface{}{"id": int64(1311768467463790321), "ratio": 0.5, "name": "foo"}
+	if actual := view.Params(); !reflect.DeepEqual(expect, actual) {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	var params *stderr.ParamsError
+	if !errors.As(stderr.FromView(view), &params) {
+		t.Fatal("expect params error in restored chain")
+	}
+	if expect, actual := int64(1311768467463790321), params.Params()["id"]; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}
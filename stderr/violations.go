@@ -0,0 +1,80 @@
+package stderr
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Violation describes why the value of a single field was rejected.
+type Violation struct {
+	// Field is the path to the field, for instance "items[0].name".
+	Field string `json:"field" yaml:"field"`
+	// Rule is the name of the rule that the value failed, for instance "required".
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// Message is the human-readable reason of the rejection.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Value is the rejected value. It is redacted by PublicPolicy, but exposed to the audience of other policies, hence
+	// should be left empty for secrets.
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Violations returns a violations typed error. When placed in a chain of errors, this type of error describes which
+// fields of the request are invalid and why. At least one violation must be supplied.
+func Violations(violations ...Violation) Error {
+	if len(violations) == 0 {
+		panic("at least one violation is required")
+	}
+	return &ViolationsError{violations: violations}
+}
+
+type ViolationsError struct {
+	violations []Violation
+}
+
+func (e *ViolationsError) Violations() []Violation {
+	return e.violations
+}
+
+func (e *ViolationsError) Is(_ error) bool {
+	return false
+}
+
+func (e *ViolationsError) Error() string {
+	if len(e.violations) == 0 {
+		return "violations: <empty>"
+	}
+
+	fields := make([]string, 0, len(e.violations))
+	for _, v := range e.violations {
+		fields = append(fields, v.Field)
+	}
+
+	return "violations: " + strings.Join(fields, ", ")
+}
+
+func (e *ViolationsError) MarshalNode() (*Node, error) {
+	jsonBytes, err := json.Marshal(violationsErrorJSON{Violations: e.violations})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Type: TypeViolations,
+		Data: jsonBytes,
+	}, nil
+}
+
+func (e *ViolationsError) UnmarshalJSON(bytes []byte) error {
+	var temp violationsErrorJSON
+	if err := json.Unmarshal(bytes, &temp); err != nil {
+		return err
+	}
+
+	e.violations = temp.Violations
+
+	return nil
+}
+
+type violationsErrorJSON struct {
+	Violations []Violation `json:"violations"`
+}
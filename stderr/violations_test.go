@@ -0,0 +1,62 @@
+package stderr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/absurdlab/pkg/stderr"
+)
+
+func TestViolations(t *testing.T) {
+	violations := []stderr.Violation{
+		{Field: "email", Rule: "email", Message: "email is malformed", Value: "foo"},
+		{Field: "items[0].quantity", Rule: "min=1", Value: float64(0)},
+	}
+
+	err := stderr.Chain(
+		stderr.Status(400),
+		stderr.Code("invalid_order"),
+		stderr.Violations(violations...),
+	)
+
+	jsonBytes, jsonErr := json.Marshal(stderr.ToView(err))
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	view := new(stderr.View)
+	if jsonErr = json.Unmarshal(jsonBytes, view); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	if expect, actual := stderr.TypeViolations, view.Context[2].Type; expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+
+	var restored *stderr.ViolationsError
+	if !errors.As(stderr.FromView(view), &restored) {
+		t.Fatal("expect violations error in restored chain")
+	}
+	if actual := restored.Violations(); !reflect.DeepEqual(violations, actual) {
+		t.Errorf("expect %v, actual %v", violations, actual)
+	}
+	if expect, actual := "violations: email, items[0].quantity", restored.Error(); expect != actual {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}
+
+func TestViolations_PublicPolicy(t *testing.T) {
+	err := stderr.Violations(stderr.Violation{Field: "password", Rule: "min=12", Message: "password is too short", Value: "hunter2"})
+
+	var restored *stderr.ViolationsError
+	if !errors.As(stderr.FromView(stderr.ToView(err).Apply(stderr.PublicPolicy())), &restored) {
+		t.Fatal("expect violations error in restored chain")
+	}
+
+	expect := []stderr.Violation{{Field: "password", Rule: "min=12", Message: "password is too short"}}
+	if actual := restored.Violations(); !reflect.DeepEqual(expect, actual) {
+		t.Errorf("expect %v, actual %v", expect, actual)
+	}
+}